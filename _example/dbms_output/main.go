@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"log"
 	"os"
 
-	_ "github.com/mattn/go-oci8"
+	"github.com/mattn/go-oci8"
 )
 
 func main() {
@@ -14,25 +16,38 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	_, err = db.Exec(`BEGIN DBMS_OUTPUT.ENABLE(10000); END;`)
+	defer db.Close()
+
+	// DBMS_OUTPUT is per session, so use a single connection
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	err = conn.Raw(func(driverConn interface{}) error {
+		return oci8.EnableServerOutput(driverConn.(driver.Conn), 10000)
+	})
 	if err != nil {
 		log.Fatal(err)
 	}
-	_, err = db.Exec(`BEGIN DBMS_OUTPUT.PUT_LINE('hello'); END;`)
+
+	_, err = conn.ExecContext(ctx, `BEGIN DBMS_OUTPUT.PUT_LINE('hello'); DBMS_OUTPUT.PUT_LINE('world'); END;`)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	var lines string
-	var status int
-	_, err = db.Exec(`BEGIN DBMS_OUTPUT.GET_LINE(:lines, :status); END;`,
-		sql.Named("lines", sql.Out{Dest: &lines}),
-		sql.Named("status", sql.Out{Dest: &status, In: true}))
+	var lines []string
+	err = conn.Raw(func(driverConn interface{}) error {
+		lines, err = oci8.ServerOutput(driverConn.(driver.Conn))
+		return err
+	})
 	if err != nil {
 		log.Fatal(err)
 	}
-	if status == 0 {
-		fmt.Println(lines)
+	for _, line := range lines {
+		fmt.Println(line)
 	}
 }
 
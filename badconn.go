@@ -0,0 +1,20 @@
+package oci8
+
+// isBadConnError returns true if the ORA error code or error means the connection is no longer usable.
+// Checks DefaultBadConnCodes, then the codes and callback set by the Connector.
+func (conn *Conn) isBadConnError(code int, err error) bool {
+	for _, badCode := range DefaultBadConnCodes {
+		if code == badCode {
+			return true
+		}
+	}
+	for _, badCode := range conn.badConnCodes {
+		if code == badCode {
+			return true
+		}
+	}
+	if conn.isBadConn != nil {
+		return conn.isBadConn(code, err)
+	}
+	return false
+}
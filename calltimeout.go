@@ -0,0 +1,89 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+	"unsafe"
+)
+
+// parseTimeout returns the milliseconds of a timeout DSN parameter, which is a duration like 30s or 500ms
+func parseTimeout(name string, value string) (C.ub4, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid %v: %v", name, value)
+	}
+	return durationToMilliseconds(duration), nil
+}
+
+// durationToMilliseconds returns the duration in milliseconds, rounded up, limited to the max of ub4
+func durationToMilliseconds(duration time.Duration) C.ub4 {
+	if duration <= 0 {
+		return 0
+	}
+	milliseconds := (duration + time.Millisecond - 1) / time.Millisecond
+	if milliseconds > math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return C.ub4(milliseconds)
+}
+
+// callTimeout returns the call timeout in milliseconds of the round trips of a call with ctx,
+// which is the time until the deadline of ctx or the call_timeout of the connection, whichever is sooner.
+// A 0 means no timeout.
+func (conn *Conn) callTimeout(ctx context.Context) C.ub4 {
+	timeout := conn.callTimeoutDefault
+	if ctx == nil {
+		return timeout
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return timeout
+	}
+
+	untilDeadline := durationToMilliseconds(time.Until(deadline))
+	if untilDeadline < 1 {
+		// the deadline has passed, the call is expected to check ctx.Err first
+		untilDeadline = 1
+	}
+	if timeout == 0 || untilDeadline < timeout {
+		return untilDeadline
+	}
+	return timeout
+}
+
+// setCallTimeout sets OCI_ATTR_CALL_TIMEOUT of the service context for the round trips of a call with ctx,
+// so a round trip that does not return, like a hung network read, is ended by the client even if OCIBreak can not reach the server
+func (conn *Conn) setCallTimeout(ctx context.Context) {
+	if conn.callTimeoutUnsupported {
+		return
+	}
+	timeout := conn.callTimeout(ctx)
+	if timeout == conn.callTimeoutCurrent {
+		return
+	}
+
+	err := conn.ociSetCallTimeout(timeout)
+	if err != nil {
+		// clients before 18c do not support OCI_ATTR_CALL_TIMEOUT, so only OCIBreak is used to end calls
+		conn.callTimeoutUnsupported = true
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		conn.logError(ctx, "call timeout error", err)
+	}
+}
+
+// ociSetCallTimeout sets OCI_ATTR_CALL_TIMEOUT of the service context in milliseconds
+func (conn *Conn) ociSetCallTimeout(timeout C.ub4) error {
+	err := conn.ociAttrSet(unsafe.Pointer(conn.svc), C.OCI_HTYPE_SVCCTX, unsafe.Pointer(&timeout), 0, C.OCI_ATTR_CALL_TIMEOUT)
+	if err != nil {
+		return err
+	}
+	conn.callTimeoutCurrent = timeout
+	return nil
+}
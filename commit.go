@@ -0,0 +1,77 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Commit modes
+const (
+	// CommitModeDefault waits for the redo to be written to the log before the commit returns
+	CommitModeDefault CommitMode = iota
+	// CommitModeNoWait returns from the commit without waiting for the redo to be written to the log (COMMIT WRITE NOWAIT).
+	// A commit can be lost if the instance fails before the redo is written.
+	CommitModeNoWait
+	// CommitModeBatch buffers the redo to be written with the redo of other transactions (COMMIT WRITE BATCH WAIT)
+	CommitModeBatch
+	// CommitModeBatchNoWait buffers the redo and returns from the commit without waiting for it to be written (COMMIT WRITE BATCH NOWAIT).
+	// A commit can be lost if the instance fails before the redo is written.
+	CommitModeBatchNoWait
+)
+
+// WithCommitMode returns a context that sets the commit mode of a transaction started with it by BeginTx,
+// or of an autocommit exec outside a transaction, overriding the commit_mode of the connection
+func WithCommitMode(ctx context.Context, mode CommitMode) context.Context {
+	return context.WithValue(ctx, commitModeKey{}, mode)
+}
+
+// commitFlags returns the OCITransCommit flags of the commit mode
+func (mode CommitMode) commitFlags() C.ub4 {
+	switch mode {
+	case CommitModeNoWait:
+		return C.OCI_TRANS_WRITENOWAIT
+	case CommitModeBatch:
+		return C.OCI_TRANS_WRITEBATCH | C.OCI_TRANS_WRITEWAIT
+	case CommitModeBatchNoWait:
+		return C.OCI_TRANS_WRITEBATCH | C.OCI_TRANS_WRITENOWAIT
+	}
+	return C.OCI_DEFAULT
+}
+
+// parseCommitMode returns the commit mode of the commit_mode DSN parameter
+func parseCommitMode(value string) (CommitMode, error) {
+	switch strings.ToUpper(value) {
+	case "DEFAULT", "WAIT":
+		return CommitModeDefault, nil
+	case "NOWAIT":
+		return CommitModeNoWait, nil
+	case "BATCH":
+		return CommitModeBatch, nil
+	case "BATCH_NOWAIT":
+		return CommitModeBatchNoWait, nil
+	}
+	return CommitModeDefault, fmt.Errorf("Invalid commit_mode: %v", value)
+}
+
+// commitFlagsFromContext returns the commit flags of the commit mode of the context, or of the connection if the context has none
+func (conn *Conn) commitFlagsFromContext(ctx context.Context) C.ub4 {
+	if mode, ok := ctx.Value(commitModeKey{}).(CommitMode); ok {
+		return mode.commitFlags()
+	}
+	return conn.commitFlags
+}
+
+// ociTransCommit calls OCITransCommit with flags
+func (conn *Conn) ociTransCommit(flags C.ub4) error {
+	result := C.OCITransCommit(
+		conn.svc,       // service context handle
+		conn.errHandle, // error handle
+		flags,          // flags, the commit mode
+	)
+	conn.addRoundTrips(1)
+	return conn.getError(result)
+}
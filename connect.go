@@ -0,0 +1,103 @@
+package oci8
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// descriptionRegexp matches the start of each DESCRIPTION of a connect descriptor
+var descriptionRegexp = regexp.MustCompile(`(?i)\(\s*DESCRIPTION\s*=`)
+
+// Host returns the connect string of the database of the connection, without the username and password.
+// With a Connector of several hosts, it is the host that served the connection.
+func (conn *Conn) Host() string {
+	return conn.host
+}
+
+// openDSNContext opens a new database connection with a parsed DSN, honoring ctx.
+// The connect timeout is the time until the deadline of ctx or the connect_timeout, whichever is sooner.
+// If ctx is done before the connection is opened, returns ctx.Err and the connection is closed once it is opened.
+func (drv *DriverStruct) openDSNContext(ctx context.Context, dsn *DSN) (driver.Conn, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	connectTimeout := dsn.connectTimeout
+	explicitTimeout := connectTimeout > 0
+	if deadline, ok := ctx.Deadline(); ok {
+		untilDeadline := time.Until(deadline)
+		if connectTimeout == 0 || untilDeadline < connectTimeout {
+			connectTimeout = untilDeadline
+		}
+	}
+	host := dsn.Connect
+	dsn.Connect = connectStringWithTimeout(dsn.Connect, connectTimeout, explicitTimeout)
+
+	open := func() (driver.Conn, error) {
+		conn, err := drv.openDSN(dsn)
+		if err != nil {
+			return nil, err
+		}
+		conn.(*Conn).host = host
+		return conn, nil
+	}
+
+	if ctx.Done() == nil {
+		return open()
+	}
+
+	type openResult struct {
+		conn driver.Conn
+		err  error
+	}
+	opened := make(chan openResult, 1)
+	go func() {
+		conn, err := open()
+		opened <- openResult{conn: conn, err: err}
+	}()
+
+	select {
+	case result := <-opened:
+		return result.conn, result.err
+	case <-ctx.Done():
+		// OCIServerAttach and OCISessionBegin can not be interrupted, so the connection is abandoned and closed once opened
+		go func() {
+			result := <-opened
+			if result.conn != nil {
+				result.conn.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+// connectStringWithTimeout returns the connect string with a connect timeout, which limits the time to connect to each address.
+// A connect descriptor gets CONNECT_TIMEOUT in each DESCRIPTION.
+// An Easy Connect string gets the Easy Connect Plus connect_timeout parameter, which needs a 19c or later client,
+// so it is only added when explicit is true, meaning the connect_timeout DSN parameter was set.
+// The connect string is not changed if the timeout is 0, it already has a connect timeout, or it is a net service name.
+func connectStringWithTimeout(connect string, timeout time.Duration, explicit bool) string {
+	if timeout <= 0 || strings.Contains(strings.ToUpper(connect), "CONNECT_TIMEOUT") {
+		return connect
+	}
+
+	// the timeout is in seconds, rounded up
+	seconds := ceilUnits(timeout, time.Second)
+
+	if strings.Contains(connect, "(") {
+		return descriptionRegexp.ReplaceAllStringFunc(connect, func(description string) string {
+			return description + "(CONNECT_TIMEOUT=" + seconds + ")"
+		})
+	}
+
+	if !explicit || !strings.ContainsAny(connect, "/:") {
+		return connect
+	}
+	if strings.Contains(connect, "?") {
+		return connect + "&connect_timeout=" + seconds
+	}
+	return connect + "?connect_timeout=" + seconds
+}
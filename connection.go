@@ -15,6 +15,9 @@ import (
 
 // Ping database connection
 func (conn *Conn) Ping(ctx context.Context) error {
+	if conn.badConn {
+		return driver.ErrBadConn
+	}
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
@@ -22,6 +25,7 @@ func (conn *Conn) Ping(ctx context.Context) error {
 	done := conn.ociBreakOnDone(ctx)
 	result := C.OCIPing(conn.svc, conn.errHandle, C.OCI_DEFAULT)
 	closeDone(done)
+	conn.addRoundTrips(1)
 
 	if result == C.OCI_SUCCESS || result == C.OCI_SUCCESS_WITH_INFO {
 		return nil
@@ -38,11 +42,10 @@ func (conn *Conn) Ping(ctx context.Context) error {
 		return ctx.Err()
 	}
 
-	conn.logger.Print("Ping error: ", err)
+	conn.logError(ctx, "Ping error", err)
 
-	switch errorCode {
-	case 28, 1012, 1033, 1034, 1089, 3113, 3114, 3135, 12528, 12537:
-		// see getError for the list of bad connection errors
+	if conn.isBadConnError(errorCode, err) {
+		conn.setBadConn()
 		return driver.ErrBadConn
 	}
 
@@ -55,6 +58,17 @@ func (conn *Conn) Close() error {
 		return nil
 	}
 	conn.closed = true
+	conn.closeReader()
+
+	conn.addMemory(-conn.stats.Memory)
+	if conn.connectorStats != nil && !conn.isReader {
+		conn.connectorStats.mutex.Lock()
+		conn.connectorStats.stats.OpenConnections--
+		conn.connectorStats.mutex.Unlock()
+	}
+
+	// the call timeout left by the last call is not used to end the session
+	conn.setCallTimeout(context.Background())
 
 	var err error
 	if useOCISessionBegin {
@@ -66,6 +80,9 @@ func (conn *Conn) Close() error {
 		); rv != C.OCI_SUCCESS {
 			err = conn.getError(rv)
 		}
+		if proxyErr := conn.ociEndProxySession(); proxyErr != nil {
+			err = proxyErr
+		}
 		if rv := C.OCIServerDetach(
 			conn.srv,
 			conn.errHandle,
@@ -104,9 +121,26 @@ func (conn *Conn) Prepare(query string) (driver.Stmt, error) {
 }
 
 // PrepareContext prepares a query with context
-func (conn *Conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
-	if conn.enableQMPlaceholders {
-		query = placeholders(query)
+func (conn *Conn) PrepareContext(ctx context.Context, query string) (driverStmt driver.Stmt, err error) {
+	if conn.badConn {
+		return nil, driver.ErrBadConn
+	}
+
+	target, err := conn.readOnlyConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if target != conn {
+		return target.PrepareContext(ctx, query)
+	}
+
+	query, numInput := parsePlaceholders(query, conn.enableQMPlaceholders)
+
+	if conn.hooks != nil && conn.hooks.OnPrepare != nil {
+		start := time.Now()
+		defer func() {
+			conn.hookPrepare(ctx, query, start, err)
+		}()
 	}
 
 	queryP := cString(query)
@@ -135,25 +169,57 @@ func (conn *Conn) PrepareContext(ctx context.Context, query string) (driver.Stmt
 			return nil, conn.getError(rv)
 		}
 
-		return &Stmt{conn: conn, stmt: *stmt, ctx: ctx, releaseMode: C.OCI_DEFAULT}, nil
+		conn.updateStats(func(stats *ConnStats) {
+			stats.StatementsPrepared++
+		})
+
+		return &Stmt{conn: conn, stmt: *stmt, ctx: ctx, releaseMode: C.OCI_DEFAULT, numInput: numInput, queryText: query}, nil
+	}
+
+	cacheOptions, _ := ctx.Value(statementCacheKey{}).(StatementCacheOptions)
+	cacheKey := query
+	if cacheOptions.Key != "" {
+		cacheKey = cacheOptions.Key
 	}
+	cacheKeyP := cString(cacheKey)
+	defer C.free(unsafe.Pointer(cacheKeyP))
 
-	if rv := C.OCIStmtPrepare2(
+	rv := C.OCIStmtPrepare2(
 		conn.svc,                // service context handle
 		stmt,                    // pointer to the statement handle returned
 		conn.errHandle,          // error handle
 		queryP,                  // statement text
 		C.ub4(len(query)),       // statement text length
-		queryP,                  // key to be used for searching the statement in the statement cache
-		C.ub4(len(query)),       // length of the key
+		cacheKeyP,               // key to be used for searching the statement in the statement cache
+		C.ub4(len(cacheKey)),    // length of the key
 		C.ub4(C.OCI_NTV_SYNTAX), // syntax - OCI_NTV_SYNTAX: syntax depends upon the version of the server
 		C.ub4(C.OCI_DEFAULT),    // mode
-	); rv != C.OCI_SUCCESS && rv != C.OCI_SUCCESS_WITH_INFO {
-		// Note that C.OCI_SUCCESS_WITH_INFO is returned the first time a statement it put into the cache
+	)
+	if rv != C.OCI_SUCCESS && rv != C.OCI_SUCCESS_WITH_INFO {
 		return nil, conn.getError(rv)
 	}
+	// Note that C.OCI_SUCCESS_WITH_INFO is returned when the statement is not found in the cache
+	cacheHit := rv == C.OCI_SUCCESS
+
+	// bypassed prepares are not counted as hits or misses, so the counts are of the statements that use the cache
+	conn.updateStats(func(stats *ConnStats) {
+		stats.StatementsPrepared++
+		switch {
+		case cacheOptions.Bypass:
+		case cacheHit:
+			stats.StatementCacheHits++
+		default:
+			stats.StatementCacheMisses++
+		}
+	})
+
+	releaseMode := C.ub4(C.OCI_DEFAULT)
+	if cacheOptions.Bypass && !cacheHit {
+		// a statement found in the cache is released normally, so it stays cached for the other statements using it
+		releaseMode = C.OCI_STRLS_CACHE_DELETE
+	}
 
-	return &Stmt{conn: conn, stmt: *stmt, ctx: ctx, releaseMode: C.OCI_DEFAULT, cacheKey: query}, nil
+	return &Stmt{conn: conn, stmt: *stmt, ctx: ctx, releaseMode: releaseMode, cacheKey: cacheKey, numInput: numInput, queryText: query}, nil
 }
 
 // Begin starts a transaction
@@ -161,18 +227,35 @@ func (conn *Conn) Begin() (driver.Tx, error) {
 	return conn.BeginTx(context.Background(), driver.TxOptions{})
 }
 
-// BeginTx starts a transaction
+// BeginTx starts a transaction.
+// A ReadOnly transaction runs on a reader session when the connection is from a Connector with ReaderHosts.
 func (conn *Conn) BeginTx(ctx context.Context, txOptions driver.TxOptions) (driver.Tx, error) {
+	if conn.badConn {
+		return nil, driver.ErrBadConn
+	}
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
-	if conn.transactionMode != C.OCI_TRANS_READWRITE {
+	if txOptions.ReadOnly {
+		return conn.beginReadOnly(ctx)
+	}
+
+	return conn.beginTx(ctx, conn.transactionMode)
+}
+
+// beginTx starts a transaction with the transaction mode
+func (conn *Conn) beginTx(ctx context.Context, transactionMode C.ub4) (*Tx, error) {
+	if conn.badConn {
+		return nil, driver.ErrBadConn
+	}
+
+	if transactionMode != C.OCI_TRANS_READWRITE {
 		if rv := C.OCITransStart(
 			conn.svc,
 			conn.errHandle,
 			0,
-			conn.transactionMode|C.OCI_TRANS_NEW, // mode is: C.OCI_TRANS_SERIALIZABLE, C.OCI_TRANS_READWRITE, or C.OCI_TRANS_READONLY
+			transactionMode|C.OCI_TRANS_NEW, // mode is: C.OCI_TRANS_SERIALIZABLE, C.OCI_TRANS_READWRITE, or C.OCI_TRANS_READONLY
 		); rv != C.OCI_SUCCESS {
 			return nil, conn.getError(rv)
 		}
@@ -180,7 +263,7 @@ func (conn *Conn) BeginTx(ctx context.Context, txOptions driver.TxOptions) (driv
 
 	conn.inTransaction = true
 
-	return &Tx{conn: conn}, nil
+	return &Tx{conn: conn, ctx: ctx, commitFlags: conn.commitFlagsFromContext(ctx)}, nil
 }
 
 // getError gets error from return result (sword) or OCIError
@@ -202,21 +285,8 @@ func (conn *Conn) getError(result C.sword) error {
 		return ErrOCIStillExecuting
 	case C.OCI_ERROR:
 		errorCode, err := conn.ociGetError()
-		switch errorCode {
-		/*
-			bad connection errors:
-			ORA-00028: your session has been killed
-			ORA-01012: Not logged on
-			ORA-01033: ORACLE initialization or shutdown in progress
-			ORA-01034: ORACLE not available
-			ORA-01089: immediate shutdown in progress - no operations are permitted
-			ORA-03113: end-of-file on communication channel
-			ORA-03114: Not Connected to Oracle
-			ORA-03135: connection lost contact
-			ORA-12528: TNS:listener: all appropriate instances are blocking new connections
-			ORA-12537: TNS:connection closed
-		*/
-		case 28, 1012, 1033, 1034, 1089, 3113, 3114, 3135, 12528, 12537:
+		if conn.isBadConnError(errorCode, err) {
+			conn.setBadConn()
 			return driver.ErrBadConn
 		}
 		return err
@@ -264,6 +334,23 @@ func (conn *Conn) ociAttrGet(paramHandle *C.OCIParam, value unsafe.Pointer, attr
 	return size, conn.getError(result)
 }
 
+// ociAttrGetHandle calls OCIAttrGet with a handle then returns attribute size and error.
+// The attribute value is stored into passed value.
+func (conn *Conn) ociAttrGetHandle(handle unsafe.Pointer, handleType C.ub4, value unsafe.Pointer, attributeType C.ub4) (C.ub4, error) {
+	var size C.ub4
+
+	result := C.OCIAttrGet(
+		handle,         // Pointer to a handle type
+		handleType,     // The handle type
+		value,          // Pointer to the storage for an attribute value
+		&size,          // The size of the attribute value
+		attributeType,  // The attribute type: https://docs.oracle.com/cd/B19306_01/appdev.102/b14250/ociaahan.htm
+		conn.errHandle, // An error handle
+	)
+
+	return size, conn.getError(result)
+}
+
 // ociAttrSet calls OCIAttrSet.
 // Only uses errHandle from conn, so can be called in conn setup after errHandle has been set.
 func (conn *Conn) ociAttrSet(
@@ -385,6 +472,7 @@ func (conn *Conn) ociLobRead(lobLocator *C.OCILobLocator, form C.ub1) ([]byte, e
 		lobLocator,     // LOB or BFILE locator
 		&lobLength,     // length of the LOB
 	)
+	conn.addRoundTrips(1)
 	if result != C.OCI_SUCCESS {
 		return nil, conn.getError(result)
 	}
@@ -420,6 +508,10 @@ func (conn *Conn) ociLobRead(lobLocator *C.OCILobLocator, form C.ub1) ([]byte, e
 		0,                          // character set ID of the buffer data. If this value is 0 then csid is set to the client's NLS_LANG or NLS_CHAR value, depending on the value of csfrm.
 		form,                       // character set form of the buffer data
 	)
+	conn.updateStats(func(stats *ConnStats) {
+		stats.RoundTrips++
+		stats.LOBBytesRead += uint64(byteAmount)
+	})
 	err := conn.getError(result)
 	if err != nil {
 		return nil, err
@@ -451,6 +543,10 @@ func (conn *Conn) ociLobWrite(lobLocator *C.OCILobLocator, form C.ub1, data []by
 		0,                        // character set ID
 		form,                     // character set form
 	)
+	conn.updateStats(func(stats *ConnStats) {
+		stats.RoundTrips++
+		stats.LOBBytesWritten += uint64(writeBytes)
+	})
 
 	return conn.getError(result)
 }
@@ -583,7 +679,10 @@ func appendSmallInt(slice []byte, num int) []byte {
 // when ctx is canceled. The returned channel must be passed to closeDone
 // once the OCI call has finished. When ctx can never be canceled, no
 // goroutine is started and nil is returned.
+// It also sets the call timeout of the round trips of the call, see setCallTimeout.
 func (conn *Conn) ociBreakOnDone(ctx context.Context) chan struct{} {
+	conn.setCallTimeout(ctx)
+
 	if ctx == nil || ctx.Done() == nil {
 		return nil
 	}
@@ -616,12 +715,13 @@ func (conn *Conn) ociBreakDone(ctx context.Context, done chan struct{}) {
 
 // ociBreak calls OCIBreak
 func (conn *Conn) ociBreak() {
+	conn.addBreak()
 	result := C.OCIBreak(
 		unsafe.Pointer(conn.svc), // service or server context handle
 		conn.errHandle,           // error handle
 	)
 	err := conn.getError(result)
 	if err != nil {
-		conn.logger.Print("OCIBreak error: ", err)
+		conn.logError(context.Background(), "OCIBreak error", err)
 	}
 }
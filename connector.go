@@ -9,15 +9,14 @@ import (
 	"log"
 )
 
-// NewConnector returns a new database connector
+// NewConnector returns a new database connector.
+// Each host is a DSN, see ParseDSN. With several hosts, a connection is opened to the first host that connects,
+// see LoadBalance, FailoverCooldown, RetryCount, and RetryDelay of Connector for how the hosts are tried.
 func NewConnector(hosts ...string) driver.Connector {
-	connector := &Connector{
+	return &Connector{
 		Logger: log.New(ioutil.Discard, "", 0),
+		hosts:  hosts,
 	}
-	if len(hosts) > 0 {
-		connector.dsnString = hosts[0]
-	}
-	return connector
 }
 
 // Driver returns the OCI8 driver
@@ -27,19 +26,67 @@ func (connector *Connector) Driver() driver.Driver {
 
 // Connect returns a new database connection
 func (connector *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := connector.connect(ctx, connector.hosts, &connector.hostsFailed, false)
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// connect returns a new database connection to one of the hosts, set up with the options of the connector.
+// A reader session is not counted in OpenConnections, as database/sql does not know about it.
+func (connector *Connector) connect(ctx context.Context, hosts []string, hostsFailed *connectorHostsFailed, isReader bool) (*Conn, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
-	connDriver, err := Driver.Open(connector.dsnString)
+	connDriver, err := connector.connectHosts(ctx, hosts, hostsFailed)
 	if err != nil {
 		return nil, err
 	}
 
 	conn := connDriver.(*Conn)
+	conn.connector = connector
 	if connector.Logger != nil {
 		conn.logger = connector.Logger
 	}
+	if connector.LevelLogger != nil {
+		conn.levelLogger = connector.LevelLogger
+		conn.slowStatementThreshold = connector.SlowStatementThreshold
+		conn.logBinds = connector.LogBindValues
+		conn.levelLogger.DebugContext(ctx, "oci8 connected", "host", conn.host)
+	}
+	conn.isReader = isReader
+	conn.connectorStats = &connector.stats
+	if !isReader {
+		connector.stats.mutex.Lock()
+		connector.stats.stats.OpenConnections++
+		connector.stats.mutex.Unlock()
+	}
+
+	conn.badConnCodes = connector.BadConnCodes
+	conn.isBadConn = connector.IsBadConn
+	if connector.Hooks != nil {
+		conn.hooks = connector.Hooks
+	}
+
+	if connector.ServerOutputHandler != nil {
+		err = conn.enableServerOutput(ctx, connector.ServerOutputBufferSize)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn.serverOutputHandler = connector.ServerOutputHandler
+	}
 
 	return conn, nil
 }
+
+// Stats returns the totals of the statistics of the connections of the connector, including closed connections.
+// Memory and OpenConnections are of the currently open connections.
+func (connector *Connector) Stats() ConnStats {
+	connector.stats.mutex.Lock()
+	stats := connector.stats.stats
+	connector.stats.mutex.Unlock()
+	return stats
+}
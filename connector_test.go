@@ -5,7 +5,12 @@ package oci8
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"sort"
 	"testing"
+	"time"
 )
 
 // TestConnector tests that a connection from sql.OpenDB with NewConnector works
@@ -44,3 +49,137 @@ func TestConnector(t *testing.T) {
 		t.Fatal("select expected: 1, received:", one)
 	}
 }
+
+// TestConnectorFailover tests that a connector of several hosts fails over to the host that connects
+func TestConnectorFailover(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	var openString string
+	if len(TestUsername) > 0 {
+		if len(TestPassword) > 0 {
+			openString = TestUsername + "/" + TestPassword + "@"
+		} else {
+			openString = TestUsername + "@"
+		}
+	}
+	invalidString := openString + "(DESCRIPTION=(CONNECT_TIMEOUT=2)(ADDRESS=(PROTOCOL=TCP)(HOST=" + TestHostInvalid + ")(PORT=1521))(CONNECT_DATA=(SERVICE_NAME=ORCL)))"
+	openString += TestHostValid
+
+	connector := NewConnector(invalidString, openString).(*Connector)
+	connector.FailoverCooldown = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	for i := 0; i < 2; i++ {
+		start := time.Now()
+		conn, err := connector.Connect(ctx)
+		if err != nil {
+			t.Fatal("connect error:", err)
+		}
+		host := conn.(*Conn).Host()
+		conn.Close()
+		if host != TestHostValid {
+			t.Fatalf("host - received: %v - expected: %v", host, TestHostValid)
+		}
+		// the second connect skips the invalid host that is cooling down
+		if i == 1 && time.Since(start) > time.Second {
+			t.Fatalf("connect elapsed - received: %v - expected less than: %v", time.Since(start), time.Second)
+		}
+	}
+}
+
+func TestConnectorHostOrder(t *testing.T) {
+	connector := NewConnector("a", "b", "c").(*Connector)
+
+	order := connector.hostOrder(3, &connector.hostsFailed)
+	if !reflect.DeepEqual(order, []int{0, 1, 2}) {
+		t.Fatalf("hostOrder - received: %v - expected: %v", order, []int{0, 1, 2})
+	}
+
+	connector.FailoverCooldown = time.Minute
+	connector.hostsFailed.setFailed(0, true)
+	order = connector.hostOrder(3, &connector.hostsFailed)
+	if !reflect.DeepEqual(order, []int{1, 2}) {
+		t.Fatalf("hostOrder - received: %v - expected: %v", order, []int{1, 2})
+	}
+
+	connector.hostsFailed.setFailed(1, true)
+	connector.hostsFailed.setFailed(2, true)
+	order = connector.hostOrder(3, &connector.hostsFailed)
+	if !reflect.DeepEqual(order, []int{0, 1, 2}) {
+		t.Fatalf("hostOrder all failed - received: %v - expected: %v", order, []int{0, 1, 2})
+	}
+
+	connector.hostsFailed.setFailed(1, false)
+	order = connector.hostOrder(3, &connector.hostsFailed)
+	if !reflect.DeepEqual(order, []int{1}) {
+		t.Fatalf("hostOrder - received: %v - expected: %v", order, []int{1})
+	}
+
+	connector.LoadBalance = true
+	connector.FailoverCooldown = 0
+	order = connector.hostOrder(3, &connector.hostsFailed)
+	sort.Ints(order)
+	if !reflect.DeepEqual(order, []int{0, 1, 2}) {
+		t.Fatalf("hostOrder load balance - received: %v - expected: %v", order, []int{0, 1, 2})
+	}
+}
+
+func TestIsConnectError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{driver.ErrBadConn, true},
+		{errors.New("ORA-12170: TNS:Connect timeout occurred"), true},
+		{errors.New("ORA-12541: TNS:no listener"), true},
+		{errors.New("ORA-01017: invalid username/password; logon denied"), false},
+		{errors.New("allocate error handle error"), false},
+	}
+
+	for _, tt := range tests {
+		actual := isConnectError(tt.err)
+		if actual != tt.expected {
+			t.Errorf("isConnectError(%v) - expected: %v, actual: %v", tt.err, tt.expected, actual)
+		}
+	}
+}
+
+// TestReadOnlyConnReader tests that a WithReadOnly statement is routed once, to the reader session, which does not route it again
+func TestReadOnlyConnReader(t *testing.T) {
+	connector := NewConnector("primary").(*Connector)
+	connector.ReaderHosts = []string{"reader"}
+	reader := &Conn{connector: connector, isReader: true}
+	conn := &Conn{connector: connector, reader: reader}
+	ctx := WithReadOnly(context.Background())
+
+	target, err := conn.readOnlyConn(ctx)
+	if err != nil {
+		t.Fatal("primary readOnlyConn error:", err)
+	}
+	if target != reader {
+		t.Fatal("primary readOnlyConn - received: not the reader - expected: the reader")
+	}
+
+	target, err = reader.readOnlyConn(ctx)
+	if err != nil {
+		t.Fatal("reader readOnlyConn error:", err)
+	}
+	if target != reader {
+		t.Fatal("reader readOnlyConn - received: another connection - expected: the reader")
+	}
+	if reader.reader != nil {
+		t.Fatal("reader opened a reader session")
+	}
+
+	target, err = reader.readerConn(ctx)
+	if err != nil {
+		t.Fatal("reader readerConn error:", err)
+	}
+	if target != reader {
+		t.Fatal("reader readerConn - received: another connection - expected: the reader")
+	}
+}
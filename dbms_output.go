@@ -0,0 +1,228 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"log"
+	"unsafe"
+)
+
+const (
+	// serverOutputLineSize is the max size in bytes of a DBMS_OUTPUT line
+	serverOutputLineSize = 32767
+	// serverOutputBatchSize is the number of lines fetched by each DBMS_OUTPUT.GET_LINES call
+	serverOutputBatchSize = 64
+)
+
+// ServerOutputHandler is called with the DBMS_OUTPUT lines produced by a statement
+type ServerOutputHandler func(lines []string)
+
+// ServerOutputLogger returns a ServerOutputHandler that prints each DBMS_OUTPUT line to logger
+func ServerOutputLogger(logger *log.Logger) ServerOutputHandler {
+	return func(lines []string) {
+		for _, line := range lines {
+			logger.Print(line)
+		}
+	}
+}
+
+// EnableServerOutput enables DBMS_OUTPUT for the session of conn.
+// A bufferSize of 0 or less means an unlimited buffer.
+func EnableServerOutput(conn driver.Conn, bufferSize int) error {
+	oci8Conn, err := toConn(conn)
+	if err != nil {
+		return err
+	}
+	return oci8Conn.enableServerOutput(context.Background(), bufferSize)
+}
+
+// DisableServerOutput disables DBMS_OUTPUT for the session of conn
+// and removes any ServerOutputHandler set on it
+func DisableServerOutput(conn driver.Conn) error {
+	oci8Conn, err := toConn(conn)
+	if err != nil {
+		return err
+	}
+	oci8Conn.serverOutputHandler = nil
+	_, err = oci8Conn.execSimple(context.Background(), "begin dbms_output.disable; end;")
+	return err
+}
+
+// ServerOutput returns the DBMS_OUTPUT lines buffered for the session of conn.
+// The lines are removed from the server buffer.
+func ServerOutput(conn driver.Conn) ([]string, error) {
+	oci8Conn, err := toConn(conn)
+	if err != nil {
+		return nil, err
+	}
+	return oci8Conn.serverOutput(context.Background())
+}
+
+// SetServerOutputHandler enables DBMS_OUTPUT for the session of conn,
+// then after each Exec the lines produced are passed to handler.
+// A nil handler stops the delivery but leaves DBMS_OUTPUT enabled.
+func SetServerOutputHandler(conn driver.Conn, bufferSize int, handler ServerOutputHandler) error {
+	oci8Conn, err := toConn(conn)
+	if err != nil {
+		return err
+	}
+	if handler != nil {
+		err = oci8Conn.enableServerOutput(context.Background(), bufferSize)
+		if err != nil {
+			return err
+		}
+	}
+	oci8Conn.serverOutputHandler = handler
+	return nil
+}
+
+// toConn returns the oci8 Conn of a driver Conn
+func toConn(conn driver.Conn) (*Conn, error) {
+	oci8Conn, ok := conn.(*Conn)
+	if !ok {
+		return nil, ErrNotConn
+	}
+	if oci8Conn.closed || oci8Conn.badConn {
+		return nil, driver.ErrBadConn
+	}
+	return oci8Conn, nil
+}
+
+// enableServerOutput calls DBMS_OUTPUT.ENABLE
+func (conn *Conn) enableServerOutput(ctx context.Context, bufferSize int) error {
+	var args []driver.NamedValue
+	if bufferSize > 0 {
+		args = []driver.NamedValue{{Ordinal: 1, Value: int64(bufferSize)}}
+	} else {
+		args = []driver.NamedValue{{Ordinal: 1, Value: nil}}
+	}
+	_, err := conn.execSimple(ctx, "begin dbms_output.enable(:1); end;", args...)
+	return err
+}
+
+// execSimple prepares, executes, then closes a statement
+func (conn *Conn) execSimple(ctx context.Context, query string, args ...driver.NamedValue) (driver.Result, error) {
+	stmt, err := conn.prepareRaw(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	return stmt.ExecContext(ctx, args)
+}
+
+// prepareRaw prepares a query without placeholder conversion
+func (conn *Conn) prepareRaw(ctx context.Context, query string) (*Stmt, error) {
+	enableQMPlaceholders := conn.enableQMPlaceholders
+	conn.enableQMPlaceholders = false
+	stmt, err := conn.PrepareContext(ctx, query)
+	conn.enableQMPlaceholders = enableQMPlaceholders
+	if err != nil {
+		return nil, err
+	}
+	return stmt.(*Stmt), nil
+}
+
+// serverOutput calls DBMS_OUTPUT.GET_LINES with an array bind until the buffer is empty
+func (conn *Conn) serverOutput(ctx context.Context) ([]string, error) {
+	stmt, err := conn.prepareRaw(ctx, "begin dbms_output.get_lines(:1, :2); end;")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	lineBuffer := C.malloc(C.size_t(serverOutputBatchSize * serverOutputLineSize))
+	defer C.free(lineBuffer)
+	lineLengths := (*[serverOutputBatchSize]C.ub2)(C.malloc(C.size_t(serverOutputBatchSize * C.sizeof_ub2)))
+	defer C.free(unsafe.Pointer(lineLengths))
+	lineIndicators := (*[serverOutputBatchSize]C.sb2)(C.malloc(C.size_t(serverOutputBatchSize * C.sizeof_sb2)))
+	defer C.free(unsafe.Pointer(lineIndicators))
+	lineCount := (*C.ub4)(C.malloc(C.sizeof_ub4))
+	defer C.free(unsafe.Pointer(lineCount))
+	*lineCount = 0
+
+	var numLines bindStruct
+	numLines.dataType = C.SQLT_INT
+	numLines.pbuf = cInt64(serverOutputBatchSize)
+	numLines.maxSize = 8
+	numLines.length = (*C.ub2)(C.malloc(C.sizeof_ub2))
+	*numLines.length = 8
+	numLines.indicator = (*C.sb2)(C.malloc(C.sizeof_sb2))
+	*numLines.indicator = 0
+	defer freeBinds([]bindStruct{numLines})
+
+	var linesBind *C.OCIBind
+	result := C.OCIBindByPos(
+		stmt.stmt,                          // The statement handle
+		&linesBind,                         // The bind handle that is implicitly allocated by this call
+		conn.errHandle,                     // An error handle
+		1,                                  // The placeholder position
+		lineBuffer,                         // An address of an array of data values
+		serverOutputLineSize,               // The maximum size possible in bytes of each data value
+		C.SQLT_CHR,                         // The data type of the values being bound
+		unsafe.Pointer(&lineIndicators[0]), // Pointer to an indicator array
+		&lineLengths[0],                    // Pointer to an array of lengths
+		nil,                                // Pointer to the array of column-level return codes
+		serverOutputBatchSize,              // The maximum number of elements in the PL/SQL array
+		lineCount,                          // Pointer to the current number of elements in the PL/SQL array
+		C.OCI_DEFAULT,                      // The mode
+	)
+	if result != C.OCI_SUCCESS {
+		return nil, conn.getError(result)
+	}
+
+	err = stmt.ociBindByPos(2, &numLines)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		*(*C.sb8)(numLines.pbuf) = serverOutputBatchSize
+		*lineCount = 0
+
+		done := conn.ociBreakOnDone(ctx)
+		err = stmt.ociStmtExecute(1, C.OCI_DEFAULT)
+		closeDone(done)
+		if err != nil && err != ErrOCISuccessWithInfo {
+			return nil, err
+		}
+
+		count := int(getInt64(numLines.pbuf))
+		for i := 0; i < count; i++ {
+			if lineIndicators[i] == -1 {
+				lines = append(lines, "")
+				continue
+			}
+			line := unsafe.Pointer(uintptr(lineBuffer) + uintptr(i*serverOutputLineSize))
+			lines = append(lines, C.GoStringN((*C.char)(line), C.int(lineLengths[i])))
+		}
+
+		if count < serverOutputBatchSize {
+			return lines, nil
+		}
+	}
+}
+
+// deliverServerOutput passes any buffered DBMS_OUTPUT lines to the connection ServerOutputHandler
+func (conn *Conn) deliverServerOutput(ctx context.Context) {
+	if conn.serverOutputHandler == nil {
+		return
+	}
+
+	lines, err := conn.serverOutput(ctx)
+	if err != nil {
+		conn.logError(ctx, "server output error", err)
+		return
+	}
+	if len(lines) > 0 {
+		conn.serverOutputHandler(lines)
+	}
+}
@@ -0,0 +1,270 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"unsafe"
+)
+
+// bindInfoSize is the number of bind variables returned by each OCIStmtGetBindInfo call
+const bindInfoSize = 32
+
+// Describe prepares query on conn and returns the select-list column metadata and the bind variable names.
+// A query is executed in OCI_DESCRIBE_ONLY mode so no rows are fetched and no bind values are needed.
+func Describe(ctx context.Context, conn driver.Conn, query string) (*Description, error) {
+	oci8Conn, err := toConn(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	driverStmt, err := oci8Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	stmt := driverStmt.(*Stmt)
+	defer stmt.Close()
+
+	description := &Description{}
+
+	description.BindNames, err = stmt.bindNames()
+	if err != nil {
+		return nil, err
+	}
+
+	var stmtType C.ub2
+	_, err = stmt.ociAttrGet(unsafe.Pointer(&stmtType), C.OCI_ATTR_STMT_TYPE)
+	if err != nil {
+		return nil, err
+	}
+	if stmtType != C.OCI_STMT_SELECT {
+		return description, nil
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	done := oci8Conn.ociBreakOnDone(ctx)
+	err = stmt.ociStmtExecute(0, C.OCI_DESCRIBE_ONLY)
+	closeDone(done)
+	if err != nil && err != ErrOCISuccessWithInfo {
+		return nil, err
+	}
+
+	description.Columns, err = stmt.describeColumns()
+	if err != nil {
+		return nil, err
+	}
+
+	return description, nil
+}
+
+// describeColumns returns the select-list column metadata of an executed or described statement
+func (stmt *Stmt) describeColumns() ([]ColumnDescription, error) {
+	var paramCount C.ub4 // number of columns in the select-list
+	_, err := stmt.ociAttrGet(unsafe.Pointer(&paramCount), C.OCI_ATTR_PARAM_COUNT)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]ColumnDescription, int(paramCount))
+	for i := 0; i < len(columns); i++ {
+		var param *C.OCIParam
+		param, err = stmt.ociParamGet(C.ub4(i + 1))
+		if err != nil {
+			return nil, err
+		}
+		columns[i], err = stmt.conn.describeColumn(param)
+		C.OCIDescriptorFree(unsafe.Pointer(param), C.OCI_DTYPE_PARAM)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return columns, nil
+}
+
+// describeColumn returns the column metadata of a select-list parameter descriptor
+func (conn *Conn) describeColumn(param *C.OCIParam) (ColumnDescription, error) {
+	var column ColumnDescription
+
+	var columnName *C.OraText // name of the column
+	size, err := conn.ociAttrGet(param, unsafe.Pointer(&columnName), C.OCI_ATTR_NAME)
+	if err != nil {
+		return column, err
+	}
+	column.Name = cGoStringN(columnName, int(size))
+
+	var dataType C.ub2 // internal data type of the column
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&dataType), C.OCI_ATTR_DATA_TYPE)
+	if err != nil {
+		return column, err
+	}
+
+	var dataSize C.ub2 // max size in bytes of the column
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&dataSize), C.OCI_ATTR_DATA_SIZE)
+	if err != nil {
+		return column, err
+	}
+
+	var precision C.sb2 // the precision, for an implicit describe it is sb2
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&precision), C.OCI_ATTR_PRECISION)
+	if err != nil {
+		return column, err
+	}
+
+	var scale C.sb1 // the scale (number of digits to the right of the decimal point)
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&scale), C.OCI_ATTR_SCALE)
+	if err != nil {
+		return column, err
+	}
+
+	var isNull C.ub1 // 0 if null values are not permitted for the column
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&isNull), C.OCI_ATTR_IS_NULL)
+	if err != nil {
+		return column, err
+	}
+
+	var charsetForm C.ub1 // character set form: SQLCS_IMPLICIT or SQLCS_NCHAR, 0 for non character columns
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&charsetForm), C.OCI_ATTR_CHARSET_FORM)
+	if err != nil {
+		return column, err
+	}
+
+	var charSize C.ub2 // declared length in characters of a character column
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&charSize), C.OCI_ATTR_CHAR_SIZE)
+	if err != nil {
+		return column, err
+	}
+
+	column.DatabaseTypeName = oracleTypeName(dataType, charsetForm, precision, scale)
+	column.DataType = int(dataType)
+	column.Size = int(dataSize)
+	column.Precision = int(precision)
+	column.Scale = int(scale)
+	column.Nullable = isNull != 0
+	column.CharsetForm = int(charsetForm)
+	column.CharLength = int(charSize)
+
+	return column, nil
+}
+
+// oracleTypeName returns the Oracle type name of an internal data type
+func oracleTypeName(dataType C.ub2, charsetForm C.ub1, precision C.sb2, scale C.sb1) string {
+	switch dataType {
+	case C.SQLT_CHR, C.SQLT_VCS:
+		if charsetForm == C.SQLCS_NCHAR {
+			return "NVARCHAR2"
+		}
+		return "VARCHAR2"
+	case C.SQLT_AFC:
+		if charsetForm == C.SQLCS_NCHAR {
+			return "NCHAR"
+		}
+		return "CHAR"
+	case C.SQLT_NUM, C.SQLT_VNU:
+		// If the precision is nonzero and scale is -127, then it is a FLOAT
+		if precision != 0 && scale == -127 {
+			return "FLOAT"
+		}
+		return "NUMBER"
+	case C.SQLT_INT, C.SQLT_UIN:
+		return "INTEGER"
+	case C.SQLT_LNG:
+		return "LONG"
+	case C.SQLT_DAT, C.SQLT_DATE:
+		return "DATE"
+	case C.SQLT_BIN:
+		return "RAW"
+	case C.SQLT_LBI:
+		return "LONG RAW"
+	case C.SQLT_BFLOAT, C.SQLT_IBFLOAT:
+		return "BINARY_FLOAT"
+	case C.SQLT_BDOUBLE, C.SQLT_IBDOUBLE:
+		return "BINARY_DOUBLE"
+	case C.SQLT_RDD:
+		return "ROWID"
+	case 208: // internal data type of UROWID, no SQLT constant
+		return "UROWID"
+	case C.SQLT_NTY:
+		return "OBJECT"
+	case C.SQLT_REF:
+		return "REF"
+	case C.SQLT_CLOB:
+		if charsetForm == C.SQLCS_NCHAR {
+			return "NCLOB"
+		}
+		return "CLOB"
+	case C.SQLT_BLOB:
+		return "BLOB"
+	case C.SQLT_BFILEE:
+		return "BFILE"
+	case C.SQLT_RSET:
+		return "REF CURSOR"
+	case C.SQLT_TIMESTAMP:
+		return "TIMESTAMP"
+	case C.SQLT_TIMESTAMP_TZ:
+		return "TIMESTAMP WITH TIME ZONE"
+	case C.SQLT_TIMESTAMP_LTZ:
+		return "TIMESTAMP WITH LOCAL TIME ZONE"
+	case C.SQLT_INTERVAL_YM:
+		return "INTERVAL YEAR TO MONTH"
+	case C.SQLT_INTERVAL_DS:
+		return "INTERVAL DAY TO SECOND"
+	}
+	return ""
+}
+
+// bindNames calls OCIStmtGetBindInfo then returns the bind variable names without duplicates
+func (stmt *Stmt) bindNames() ([]string, error) {
+	var found C.sb4
+	bindNameP := make([]*C.OraText, bindInfoSize)
+	bindNameLengths := make([]C.ub1, bindInfoSize)
+	indicatorNameP := make([]*C.OraText, bindInfoSize)
+	indicatorNameLengths := make([]C.ub1, bindInfoSize)
+	duplicates := make([]C.ub1, bindInfoSize)
+	bindHandles := make([]*C.OCIBind, bindInfoSize)
+
+	var names []string
+	for start := 1; ; start += bindInfoSize {
+		result := C.OCIStmtGetBindInfo(
+			stmt.stmt,                // statement handle
+			stmt.conn.errHandle,      // error handle
+			bindInfoSize,             // number of elements in each array
+			C.ub4(start),             // position of the bind variable at which to start getting bind information
+			&found,                   // absolute value is the number of bind variables, negative if there are more than size
+			&bindNameP[0],            // array of pointers to bind variable names
+			&bindNameLengths[0],      // array of bind variable name lengths
+			&indicatorNameP[0],       // array of pointers to indicator variable names
+			&indicatorNameLengths[0], // array of indicator variable name lengths
+			&duplicates[0],           // array of flags, nonzero if the bind variable is a duplicate
+			&bindHandles[0],          // array of bind handles, nil if not yet bound
+		)
+		if result == C.OCI_NO_DATA {
+			// statement has no bind variables
+			return names, nil
+		}
+		if result != C.OCI_SUCCESS {
+			return nil, stmt.conn.getError(result)
+		}
+
+		total := int(found)
+		if total < 0 {
+			total = -total
+		}
+
+		for i := 0; i < bindInfoSize && start+i <= total; i++ {
+			if duplicates[i] != 0 {
+				continue
+			}
+			names = append(names, cGoStringN(bindNameP[i], int(bindNameLengths[i])))
+		}
+
+		if start+bindInfoSize > total {
+			return names, nil
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package oci8
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Description returns the connect descriptor as a DESCRIPTION, like:
+// (DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(HOST=host)(PORT=1521))(CONNECT_DATA=(SERVICE_NAME=service)))
+func (descriptor *ConnectDescriptor) Description() (string, error) {
+	addresses, err := descriptor.addresses()
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	builder.WriteString("(DESCRIPTION=")
+	if descriptor.ConnectTimeout > 0 {
+		builder.WriteString("(CONNECT_TIMEOUT=" + ceilUnits(descriptor.ConnectTimeout, time.Second) + ")")
+	}
+	if descriptor.RetryCount > 0 {
+		builder.WriteString("(RETRY_COUNT=" + strconv.Itoa(descriptor.RetryCount) + ")")
+	}
+	if descriptor.RetryDelay > 0 {
+		builder.WriteString("(RETRY_DELAY=" + ceilUnits(descriptor.RetryDelay, time.Second) + ")")
+	}
+	if descriptor.SDU > 0 {
+		builder.WriteString("(SDU=" + strconv.Itoa(descriptor.SDU) + ")")
+	}
+	if descriptor.ExpireTime > 0 {
+		builder.WriteString("(EXPIRE_TIME=" + ceilUnits(descriptor.ExpireTime, time.Minute) + ")")
+	}
+
+	protocol := descriptor.protocol()
+	if len(addresses) > 1 {
+		builder.WriteString("(ADDRESS_LIST=")
+	}
+	for _, address := range addresses {
+		builder.WriteString("(ADDRESS=(PROTOCOL=" + protocol + ")(HOST=" + address.host + ")(PORT=" + address.port + "))")
+	}
+	if len(addresses) > 1 {
+		builder.WriteString(")")
+	}
+
+	builder.WriteString("(CONNECT_DATA=")
+	if descriptor.ServiceName != "" {
+		builder.WriteString("(SERVICE_NAME=" + descriptor.ServiceName + ")")
+	} else {
+		builder.WriteString("(SID=" + descriptor.SID + ")")
+	}
+	if descriptor.PoolConnectionClass != "" {
+		builder.WriteString("(SERVER=POOLED)(POOL_CONNECTION_CLASS=" + descriptor.PoolConnectionClass + ")")
+	}
+	builder.WriteString(")")
+
+	if descriptor.SSLServerDNMatch || descriptor.SSLServerCertDN != "" || descriptor.WalletLocation != "" {
+		builder.WriteString("(SECURITY=")
+		if descriptor.SSLServerDNMatch {
+			builder.WriteString("(SSL_SERVER_DN_MATCH=ON)")
+		}
+		if descriptor.SSLServerCertDN != "" {
+			builder.WriteString("(SSL_SERVER_CERT_DN=" + quoteDescriptorValue(descriptor.SSLServerCertDN) + ")")
+		}
+		if descriptor.WalletLocation != "" {
+			builder.WriteString("(MY_WALLET_DIRECTORY=" + quoteDescriptorValue(descriptor.WalletLocation) + ")")
+		}
+		builder.WriteString(")")
+	}
+
+	builder.WriteString(")")
+	return builder.String(), nil
+}
+
+// EasyConnect returns the connect descriptor as an Easy Connect Plus connect string, which needs a 19c or later client, like:
+// tcps://host1:1521,host2:1521/service?connect_timeout=10&ssl_server_dn_match=on
+// A SID can not be used with Easy Connect.
+func (descriptor *ConnectDescriptor) EasyConnect() (string, error) {
+	if descriptor.SID != "" && descriptor.ServiceName == "" {
+		return "", errors.New("connect descriptor SID can not be used with Easy Connect")
+	}
+	addresses, err := descriptor.addresses()
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	protocol := descriptor.protocol()
+	if protocol != "TCP" {
+		builder.WriteString(strings.ToLower(protocol) + "://")
+	}
+	for i, address := range addresses {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString(net.JoinHostPort(address.host, address.port))
+	}
+	builder.WriteString("/" + descriptor.ServiceName)
+	if descriptor.PoolConnectionClass != "" {
+		builder.WriteString(":pooled")
+	}
+
+	var params []string
+	if descriptor.ConnectTimeout > 0 {
+		params = append(params, "connect_timeout="+ceilUnits(descriptor.ConnectTimeout, time.Second))
+	}
+	if descriptor.RetryCount > 0 {
+		params = append(params, "retry_count="+strconv.Itoa(descriptor.RetryCount))
+	}
+	if descriptor.RetryDelay > 0 {
+		params = append(params, "retry_delay="+ceilUnits(descriptor.RetryDelay, time.Second))
+	}
+	if descriptor.SDU > 0 {
+		params = append(params, "sdu="+strconv.Itoa(descriptor.SDU))
+	}
+	if descriptor.ExpireTime > 0 {
+		params = append(params, "expire_time="+ceilUnits(descriptor.ExpireTime, time.Minute))
+	}
+	if descriptor.PoolConnectionClass != "" {
+		params = append(params, "pool_connection_class="+descriptor.PoolConnectionClass)
+	}
+	if descriptor.SSLServerDNMatch {
+		params = append(params, "ssl_server_dn_match=on")
+	}
+	if descriptor.SSLServerCertDN != "" {
+		params = append(params, "ssl_server_cert_dn="+quoteDescriptorValue(descriptor.SSLServerCertDN))
+	}
+	if descriptor.WalletLocation != "" {
+		params = append(params, "wallet_location="+quoteDescriptorValue(descriptor.WalletLocation))
+	}
+	if len(params) > 0 {
+		builder.WriteString("?" + strings.Join(params, "&"))
+	}
+
+	return builder.String(), nil
+}
+
+// setParameter sets the field of the connect descriptor of a ParseDSN parameter
+func (descriptor *ConnectDescriptor) setParameter(name string, value string) error {
+	var err error
+	switch name {
+	case "hosts":
+		descriptor.Hosts = strings.Split(value, ",")
+	case "port":
+		descriptor.Port, err = strconv.Atoi(value)
+	case "service_name":
+		descriptor.ServiceName = value
+	case "sid":
+		descriptor.SID = value
+	case "protocol":
+		descriptor.Protocol = value
+	case "wallet_location":
+		descriptor.WalletLocation = value
+	case "ssl_server_dn_match":
+		descriptor.SSLServerDNMatch, err = strconv.ParseBool(value)
+	case "ssl_server_cert_dn":
+		descriptor.SSLServerCertDN = value
+	case "retry_count":
+		descriptor.RetryCount, err = strconv.Atoi(value)
+	case "retry_delay":
+		descriptor.RetryDelay, err = time.ParseDuration(value)
+	case "sdu":
+		descriptor.SDU, err = strconv.Atoi(value)
+	case "expire_time":
+		descriptor.ExpireTime, err = time.ParseDuration(value)
+	case "pool_connection_class":
+		descriptor.PoolConnectionClass = value
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %v: %v", name, value)
+	}
+	return nil
+}
+
+// addresses returns the host and port of each of the hosts, after checking the fields of the connect descriptor
+func (descriptor *ConnectDescriptor) addresses() ([]descriptorAddress, error) {
+	if len(descriptor.Hosts) == 0 {
+		return nil, ErrConnectDescriptorHosts
+	}
+	if (descriptor.ServiceName == "") == (descriptor.SID == "") {
+		return nil, ErrConnectDescriptorService
+	}
+	if strings.ContainsAny(descriptor.ServiceName+descriptor.SID+descriptor.PoolConnectionClass, "()=&?,/ ") {
+		return nil, errors.New("invalid connect descriptor service name, SID, or pool connection class")
+	}
+	switch descriptor.protocol() {
+	case "TCP", "TCPS":
+	default:
+		return nil, fmt.Errorf("invalid connect descriptor protocol: %v", descriptor.Protocol)
+	}
+
+	defaultPort := "1521"
+	if descriptor.Port > 0 {
+		defaultPort = strconv.Itoa(descriptor.Port)
+	}
+
+	addresses := make([]descriptorAddress, len(descriptor.Hosts))
+	for i, host := range descriptor.Hosts {
+		address := descriptorAddress{host: host, port: defaultPort}
+		// a host with a port, or an IPv6 address in brackets
+		if strings.HasPrefix(host, "[") || strings.Count(host, ":") == 1 {
+			var err error
+			address.host, address.port, err = net.SplitHostPort(host)
+			if err != nil {
+				// an IPv6 address in brackets without a port
+				address.host, address.port = strings.Trim(host, "[]"), defaultPort
+			}
+		}
+		if address.host == "" || strings.ContainsAny(address.host, "()=/?,") {
+			return nil, fmt.Errorf("invalid connect descriptor host: %v", host)
+		}
+		if _, err := strconv.ParseUint(address.port, 10, 16); err != nil {
+			return nil, fmt.Errorf("invalid connect descriptor port: %v", host)
+		}
+		addresses[i] = address
+	}
+	return addresses, nil
+}
+
+// protocol returns the upper case protocol, defaults to TCP
+func (descriptor *ConnectDescriptor) protocol() string {
+	if descriptor.Protocol == "" {
+		return "TCP"
+	}
+	return strings.ToUpper(descriptor.Protocol)
+}
+
+// ceilUnits returns the duration in units, rounded up
+func ceilUnits(duration time.Duration, unit time.Duration) string {
+	return strconv.FormatInt(int64((duration+unit-1)/unit), 10)
+}
+
+// quoteDescriptorValue returns the value in double quotes if it has characters that end a value
+func quoteDescriptorValue(value string) string {
+	if strings.ContainsAny(value, "()=,&? \"") {
+		return `"` + strings.Replace(value, `"`, `\"`, -1) + `"`
+	}
+	return value
+}
@@ -0,0 +1,144 @@
+// +build go1.10
+
+package oci8
+
+import (
+	"context"
+	"database/sql/driver"
+	"math/rand"
+	"time"
+)
+
+// connectHosts opens a new database connection to the first of the hosts that connects.
+// The hosts are tried in order, or in a random order with LoadBalance, skipping hosts that are cooling down.
+// When all hosts fail with connect errors, they are tried again RetryCount times with a doubling RetryDelay.
+// An error that is not a connect error, like invalid credentials, is returned without trying the other hosts.
+func (connector *Connector) connectHosts(ctx context.Context, hosts []string, hostsFailed *connectorHostsFailed) (driver.Conn, error) {
+	if len(hosts) == 0 {
+		conn, _, err := connector.connectHost(ctx, "")
+		return conn, err
+	}
+
+	var lastErr error
+	delay := connector.RetryDelay
+	for retry := 0; retry <= connector.RetryCount; retry++ {
+		if retry > 0 && delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			}
+			delay *= 2
+		}
+
+		for _, index := range connector.hostOrder(len(hosts), hostsFailed) {
+			conn, host, err := connector.connectHost(ctx, hosts[index])
+			if err == nil {
+				hostsFailed.setFailed(index, false)
+				return conn, nil
+			}
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if !isConnectError(err) {
+				return nil, err
+			}
+
+			hostsFailed.setFailed(index, true)
+			if connector.LevelLogger != nil {
+				connector.LevelLogger.WarnContext(ctx, "oci8 connect failed", "host", host, "retry", retry, "code", oraCode(err), "error", err)
+			}
+			lastErr = err
+		}
+	}
+
+	return nil, lastErr
+}
+
+// connectHost opens a new database connection with the DSN of one host, then returns it with the connect string of the host
+func (connector *Connector) connectHost(ctx context.Context, dsnString string) (driver.Conn, string, error) {
+	dsn, err := ParseDSN(dsnString)
+	if err != nil {
+		return nil, "", err
+	}
+	if connector.Events {
+		dsn.enableEvents = true
+	}
+	if connector.TargetUser != "" {
+		dsn.targetUser = connector.TargetUser
+	}
+	host := dsn.Connect
+
+	conn, err := Driver.openDSNContext(ctx, dsn)
+	return conn, host, err
+}
+
+// hostOrder returns the indexes of the hosts to try, in order or in a random order with LoadBalance.
+// The hosts that are cooling down are left out, unless all of them are.
+func (connector *Connector) hostOrder(count int, hostsFailed *connectorHostsFailed) []int {
+	var order []int
+	if connector.LoadBalance {
+		order = rand.Perm(count)
+	} else {
+		order = make([]int, count)
+		for i := range order {
+			order[i] = i
+		}
+	}
+
+	if connector.FailoverCooldown <= 0 {
+		return order
+	}
+
+	now := time.Now()
+	available := make([]int, 0, len(order))
+	hostsFailed.mutex.Lock()
+	for _, index := range order {
+		failedAt, ok := hostsFailed.failedAt[index]
+		if !ok || now.Sub(failedAt) >= connector.FailoverCooldown {
+			available = append(available, index)
+		}
+	}
+	hostsFailed.mutex.Unlock()
+
+	if len(available) == 0 {
+		return order
+	}
+	return available
+}
+
+// setFailed sets or clears the connect failure time of the host
+func (hostsFailed *connectorHostsFailed) setFailed(index int, failed bool) {
+	hostsFailed.mutex.Lock()
+	if failed {
+		if hostsFailed.failedAt == nil {
+			hostsFailed.failedAt = make(map[int]time.Time)
+		}
+		hostsFailed.failedAt[index] = time.Now()
+	} else {
+		delete(hostsFailed.failedAt, index)
+	}
+	hostsFailed.mutex.Unlock()
+}
+
+// isConnectError returns true if the error of opening a connection means the host can not be reached,
+// so the next host is tried
+func isConnectError(err error) bool {
+	if err == driver.ErrBadConn {
+		return true
+	}
+	code := oraCode(err)
+	if code == 0 {
+		return false
+	}
+	for _, codes := range [][]int{DefaultBadConnCodes, connectErrorCodes} {
+		for i := range codes {
+			if codes[i] == code {
+				return true
+			}
+		}
+	}
+	return false
+}
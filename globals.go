@@ -15,8 +15,8 @@ import (
 	"io/ioutil"
 	"log"
 	"reflect"
-	"regexp"
 	"strconv"
+	"sync"
 	"time"
 	"unsafe"
 )
@@ -36,9 +36,18 @@ type (
 		prefetchMemory       C.ub4
 		timeLocation         *time.Location
 		transactionMode      C.ub4
+		commitFlags          C.ub4
+		disableAutocommit    bool
+		resetPackages        bool
 		enableQMPlaceholders bool
+		enableEvents         bool
 		operationMode        C.ub4
 		stmtCacheSize        C.ub4
+		callTimeout          C.ub4 // milliseconds
+		sendTimeout          C.ub4 // milliseconds
+		receiveTimeout       C.ub4 // milliseconds
+		connectTimeout       time.Duration
+		targetUser           string // the client user the session runs as, with Username as the proxy user that authenticates
 	}
 
 	// DriverStruct is Oracle driver struct
@@ -46,39 +55,416 @@ type (
 		// Logger is used to log connection ping errors, defaults to discard
 		// To log set it to something like: log.New(os.Stderr, "oci8 ", log.Ldate|log.Ltime|log.LUTC|log.Lshortfile)
 		Logger *log.Logger
+		// LevelLogger, when set, logs statements at debug level, OCI_SUCCESS_WITH_INFO at warn level, and errors with their ORA code at error level.
+		// A *slog.Logger can be used.
+		LevelLogger LevelLogger
+		// SlowStatementThreshold, when more than 0, logs statements that take at least that long at warn level to the LevelLogger
+		SlowStatementThreshold time.Duration
+		// LogBindValues, when true, logs the bind values to the LevelLogger. By default only their types are logged.
+		LogBindValues bool
+		// Hooks, when set, are called by connections opened with Open
+		Hooks *Hooks
+	}
+
+	// LevelLogger is a leveled logger of messages with alternating key and value arguments, the same as *slog.Logger
+	LevelLogger interface {
+		DebugContext(ctx context.Context, msg string, args ...interface{})
+		WarnContext(ctx context.Context, msg string, args ...interface{})
+		ErrorContext(ctx context.Context, msg string, args ...interface{})
+	}
+
+	// Hooks are called around database calls for tracing and metrics.
+	// Any of the functions can be nil. The functions are called synchronously on the goroutine using the connection.
+	Hooks struct {
+		// OnPrepare is called after a statement is prepared
+		OnPrepare func(ctx context.Context, event HookEvent)
+		// OnExecStart is called before a statement is executed.
+		// The returned context is passed to OnExecEnd and OnFetch, so it can carry a span.
+		OnExecStart func(ctx context.Context, event HookEvent) context.Context
+		// OnExecEnd is called after a statement is executed
+		OnExecEnd func(ctx context.Context, event HookEvent)
+		// OnFetch is called once when the rows of a query are done, at the end of the rows, on an error, or on close.
+		// RowsFetched and Duration are the totals of all the fetches.
+		OnFetch func(ctx context.Context, event HookEvent)
+		// OnCommit is called after a transaction is committed or rolled back
+		OnCommit func(ctx context.Context, event HookEvent)
+	}
+
+	// HookEvent is the information passed to Hooks
+	HookEvent struct {
+		Query         string        // the SQL text
+		StatementType string        // like SELECT, INSERT, or BEGIN, empty for prepare and commit
+		NumBinds      int           // the number of bound values
+		RowsAffected  int64         // rows affected, for OnExecEnd of a statement that is not a query
+		RowsFetched   int64         // rows fetched, for OnFetch
+		Rollback      bool          // true for OnCommit of a rollback
+		Duration      time.Duration // the time of the call, 0 for OnExecStart
+		Err           error         // the error of the call
 	}
 
 	// Connector is the sql driver connector
 	Connector struct {
 		// Logger is used to log connection ping errors
 		Logger *log.Logger
+		// LevelLogger, SlowStatementThreshold, and LogBindValues, when LevelLogger is set,
+		// override the ones of the Driver, see DriverStruct
+		LevelLogger            LevelLogger
+		SlowStatementThreshold time.Duration
+		LogBindValues          bool
+
+		// ServerOutputHandler, when set, enables DBMS_OUTPUT on each new connection
+		// and is called with the lines produced by each Exec
+		ServerOutputHandler ServerOutputHandler
+		// ServerOutputBufferSize is the DBMS_OUTPUT buffer size used with ServerOutputHandler.
+		// A 0 means unlimited.
+		ServerOutputBufferSize int
+
+		// Events, when true, creates each connection environment in OCI_EVENTS mode so Subscribe can be used.
+		// This is the same as the events DSN parameter.
+		Events bool
+
+		// BadConnCodes are ORA error codes, in addition to DefaultBadConnCodes, that mean the connection is no longer usable
+		BadConnCodes []int
+		// IsBadConn, when set, is called with the ORA error code and error of errors that are not in the bad connection codes.
+		// Returning true means the connection is no longer usable.
+		IsBadConn func(code int, err error) bool
+
+		// Hooks, when set, are called by connections of the connector, overriding the Hooks of the Driver
+		Hooks *Hooks
+
+		// LoadBalance, when true, tries the hosts of the connector in a random order for each connection instead of in order
+		LoadBalance bool
+		// FailoverCooldown is how long a host that failed with a connect error is skipped by later connections.
+		// A 0 means failed hosts are not skipped. When all the hosts are cooling down, they are all tried.
+		FailoverCooldown time.Duration
+		// RetryCount is the number of times the hosts are tried again when they all fail with connect errors
+		RetryCount int
+		// RetryDelay is the delay before the first retry, which is doubled for each later retry
+		RetryDelay time.Duration
+
+		// TargetUser, when set, is the client user the sessions of the connector run as with proxy authentication.
+		// The username and password of the DSN are of the proxy user, the middle-tier account that authenticates.
+		// It overrides the target_user of a proxy_user[target_user] username of the DSN.
+		// This needs: alter user target_user grant connect through proxy_user
+		TargetUser string
 
-		dsnString string
+		// ReaderHosts are the DSNs of read-only databases, like Active Data Guard standbys.
+		// Read-only transactions and statements prepared with a WithReadOnly context run on a reader session,
+		// which each connection opens when first needed to one of the reader hosts.
+		ReaderHosts []string
+
+		hosts         []string // the DSN of each host
+		hostsFailed   connectorHostsFailed
+		readersFailed connectorHostsFailed
+		stats         connectorStats
 	}
 
 	// Conn is Oracle connection
 	Conn struct {
-		svc                  *C.OCISvcCtx
-		srv                  *C.OCIServer
-		env                  *C.OCIEnv
-		errHandle            *C.OCIError
-		usrSession           *C.OCISession
-		txHandle             *C.OCITrans
-		prefetchRows         C.ub4
-		prefetchMemory       C.ub4
-		transactionMode      C.ub4
-		operationMode        C.ub4
-		stmtCacheSize        C.ub4
-		inTransaction        bool
-		enableQMPlaceholders bool
-		closed               bool
-		timeLocation         *time.Location
-		logger               *log.Logger
+		breaks                 uint64 // updated atomically by ociBreak, first for 64-bit alignment
+		svc                    *C.OCISvcCtx
+		srv                    *C.OCIServer
+		env                    *C.OCIEnv
+		errHandle              *C.OCIError
+		usrSession             *C.OCISession
+		proxySession           *C.OCISession // the session of the proxy user that authenticates for the target user
+		txHandle               *C.OCITrans
+		prefetchRows           C.ub4
+		prefetchMemory         C.ub4
+		transactionMode        C.ub4
+		commitFlags            C.ub4 // OCITransCommit flags of the commit mode
+		operationMode          C.ub4
+		stmtCacheSize          C.ub4
+		callTimeoutDefault     C.ub4 // call_timeout in milliseconds, 0 means none
+		callTimeoutCurrent     C.ub4 // OCI_ATTR_CALL_TIMEOUT currently set on the service context
+		callTimeoutUnsupported bool  // set when the client does not support OCI_ATTR_CALL_TIMEOUT
+		stats                  ConnStats
+		connectorStats         *connectorStats // the totals of the Connector of the connection, nil if opened by the Driver
+		inTransaction          bool
+		disableAutocommit      bool     // when true, statements outside BeginTx are not committed
+		savepoints             []string // savepoint names of the current transaction, oldest first
+		enableQMPlaceholders   bool
+		enableEvents           bool
+		resetPackages          bool // when true, ResetSession calls DBMS_SESSION.RESET_PACKAGE
+		badConn                bool // set when an error shows the connection is no longer usable
+		badConnCodes           []int
+		isBadConn              func(code int, err error) bool
+		hooks                  *Hooks
+		host                   string // the connect string of the database, without the connect timeout
+		connector              *Connector
+		reader                 *Conn // the reader session of the connection, opened when first needed
+		readerTx               bool  // when true, a read-only transaction is running on the reader session
+		isReader               bool  // when true, the connection is the reader session of another connection
+		closed                 bool
+		timeLocation           *time.Location
+		logger                 *log.Logger
+		levelLogger            LevelLogger
+		slowStatementThreshold time.Duration
+		logBinds               bool // when true, bind values are logged instead of redacted
+		serverOutputHandler    ServerOutputHandler
+	}
+
+	// EventType is the type of a database change notification event
+	EventType uint32
+
+	// Operation is a bit mask of the operations of a database change
+	Operation uint32
+
+	// SubscriptionOptions are the options of a Continuous Query Notification subscription
+	SubscriptionOptions struct {
+		// Rowids, when true, includes the ROWIDs of the changed rows in the events
+		Rowids bool
+		// Operations filters the operations that generate events, 0 means all operations
+		Operations Operation
+		// QueryLevel, when true, only notifies when the result of a registered query changes
+		// instead of when any of the tables of the query change
+		QueryLevel bool
+		// BestEffort, when true with QueryLevel, allows false positives to lower the server overhead
+		BestEffort bool
+		// Reliable, when true, persists the notifications in the database so they survive an instance failure
+		Reliable bool
+		// PurgeOnNotify, when true, removes the subscription after the first notification
+		PurgeOnNotify bool
+		// Timeout is the time until the subscription is removed by the server, 0 means no timeout
+		Timeout time.Duration
+		// ChangeLag is the number of transactions to wait before notifying, 0 means notify on each commit
+		ChangeLag int
+		// Port is the client port that receives the notifications, 0 means a random port
+		Port int
+		// Address is the client IP address that receives the notifications, empty means the default address
+		Address string
+		// BufferSize is the buffer size of the Events channel
+		BufferSize int
+	}
+
+	// Subscription is a Continuous Query Notification subscription
+	Subscription struct {
+		conn         *Conn
+		subscription *C.OCISubscription
+		errHandle    *C.OCIError // error handle used by the notification callback
+		id           *C.ub8      // C allocated subscription id, passed as context to the notification callback
+		events       chan ChangeEvent
+		done         chan struct{}
+		doneOnce     sync.Once
+		mutex        sync.RWMutex
+		closed       bool
+	}
+
+	// ChangeEvent is a database change notification event
+	ChangeEvent struct {
+		Type     EventType
+		Database string
+		Tables   []TableChange // changed tables of an EventObjectChange
+		Queries  []QueryChange // changed queries of an EventQueryChange
+	}
+
+	// TableChange is a change to a table
+	TableChange struct {
+		Name      string
+		Operation Operation
+		Rows      []RowChange // only set when SubscriptionOptions.Rowids is true and OperationAllRows is not set
+	}
+
+	// RowChange is a change to a row
+	RowChange struct {
+		Rowid     string
+		Operation Operation
+	}
+
+	// QueryChange is a change to the result of a registered query
+	QueryChange struct {
+		ID        uint64
+		Operation EventType
+		Tables    []TableChange
+	}
+
+	// Visibility is whether an enqueue or dequeue is part of the current transaction
+	Visibility int
+
+	// DequeueMode is the locking behavior of a dequeue
+	DequeueMode int
+
+	// Navigation is the position of the message that is dequeued
+	Navigation int
+
+	// MessageState is the state of a dequeued message
+	MessageState int
+
+	// Queue is an Oracle Advanced Queuing queue
+	Queue struct {
+		// EnqueueOptions are used by Enqueue
+		EnqueueOptions EnqueueOptions
+		// DequeueOptions are used by Dequeue
+		DequeueOptions DequeueOptions
+
+		conn        *Conn
+		name        string
+		payloadType *C.OCIType
+		attributes  []queueAttribute // attributes of an object payload type, nil for a RAW payload
+	}
+
+	// queuePayloads are the C arrays of message properties, payloads, indicators, and message ids of an enqueue or dequeue
+	queuePayloads struct {
+		count         int
+		properties    []*C.OCIAQMsgProperties // C allocated array
+		payloads      []unsafe.Pointer        // C allocated array, RAW is *C.OCIRaw and object is the object instance
+		indicators    []unsafe.Pointer        // C allocated array, RAW is a pointer into rawIndicators and object is the null structure
+		ids           []*C.OCIRaw             // C allocated array
+		rawIndicators []C.OCIInd              // C allocated array
+	}
+
+	// queueAttribute is an attribute of an object payload type
+	queueAttribute struct {
+		name     string
+		typeCode C.OCITypeCode
+	}
+
+	// EnqueueOptions are the options of an enqueue
+	EnqueueOptions struct {
+		Visibility Visibility
+	}
+
+	// DequeueOptions are the options of a dequeue
+	DequeueOptions struct {
+		Consumer    string // consumer name for multi-consumer queues
+		Mode        DequeueMode
+		Navigation  Navigation
+		Visibility  Visibility
+		Wait        time.Duration // time to wait for a message in whole seconds, rounded up, 0 means no wait and WaitForever means wait forever
+		Correlation string        // only dequeue messages with this correlation, can contain % and _ wildcards
+		MessageID   []byte        // only dequeue the message with this message ID
+		Condition   string        // only dequeue messages matching this condition on the payload or message properties
+	}
+
+	// Message is an Advanced Queuing message
+	Message struct {
+		ID             []byte                 // message ID, set by Enqueue and Dequeue
+		Raw            []byte                 // payload of a RAW queue
+		Object         map[string]interface{} // payload of an object type queue, keyed by attribute name
+		Priority       int
+		Delay          time.Duration // time before the message can be dequeued
+		Expiration     time.Duration // time the message can be dequeued after the delay, 0 means never expires
+		Correlation    string
+		ExceptionQueue string
+
+		// only set by Dequeue
+		Attempts    int
+		EnqueueTime time.Time
+		State       MessageState
+		OriginalID  []byte
+	}
+
+	// XID is the identifier of a branch of a distributed (XA) transaction
+	XID struct {
+		FormatID            int32  // format of the identifier, -1 is a null XID
+		GlobalTransactionID []byte // global transaction ID, at most 64 bytes
+		BranchQualifier     []byte // branch qualifier, at most 64 bytes
+	}
+
+	// XAStartMode is how XAStart attaches to a global transaction
+	XAStartMode int
+
+	// CommitMode is how a commit waits for the redo to be written
+	CommitMode int
+
+	// commitModeKey is the context key of the commit mode of a transaction
+	commitModeKey struct{}
+
+	// readOnlyKey is the context key of statements that run on a reader session
+	readOnlyKey struct{}
+
+	// ConnectDescriptor are the fields of a connect descriptor, which builds a DESCRIPTION or an Easy Connect Plus connect string
+	// so the connect string does not have to be written and escaped by hand
+	ConnectDescriptor struct {
+		// Hosts are the host names or IP addresses of the database listeners, each with an optional :port
+		Hosts []string
+		// Port is the port of the hosts without one, defaults to 1521
+		Port int
+		// ServiceName is the database service name. Either ServiceName or SID is needed.
+		ServiceName string
+		// SID is the database SID, which can not be used with Easy Connect
+		SID string
+		// Protocol is TCP or TCPS, defaults to TCP
+		Protocol string
+		// WalletLocation is the directory of the wallet used with TCPS
+		WalletLocation string
+		// SSLServerDNMatch, when true, checks that the distinguished name of the server certificate matches the service
+		SSLServerDNMatch bool
+		// SSLServerCertDN is the distinguished name the server certificate must have, used with SSLServerDNMatch
+		SSLServerCertDN string
+		// RetryCount is the number of times the hosts are tried again when the connect fails
+		RetryCount int
+		// RetryDelay is the delay between retries, in seconds precision
+		RetryDelay time.Duration
+		// SDU is the session data unit size in bytes
+		SDU int
+		// ExpireTime is the interval of the dead connection detection probes, in minutes precision
+		ExpireTime time.Duration
+		// ConnectTimeout is the max time to connect to each host, in seconds precision
+		ConnectTimeout time.Duration
+		// PoolConnectionClass is the Database Resident Connection Pooling connection class, which connects to a pooled server
+		PoolConnectionClass string
+	}
+
+	// descriptorAddress is a host and port of a connect descriptor
+	descriptorAddress struct {
+		host string
+		port string
+	}
+
+	// StatementCacheOptions are the statement cache options of a statement, set with WithStatementCache
+	StatementCacheOptions struct {
+		// Bypass, when true, does not add the statement to the statement cache when it is closed.
+		// A statement that is already cached is used and stays cached.
+		// Bypassed prepares are not counted in StatementCacheHits and StatementCacheMisses.
+		Bypass bool
+		// Key is the statement cache key, instead of the statement text
+		Key string
+	}
+
+	// statementCacheKey is the context key of the statement cache options of a statement
+	statementCacheKey struct{}
+
+	// ConnStats are the statistics of a connection, or the totals of the connections of a Connector
+	ConnStats struct {
+		StatementCacheSize   int    // the size of the statement cache, 0 when statement caching is disabled
+		StatementCacheHits   uint64 // the number of prepares that found the statement in the statement cache
+		StatementCacheMisses uint64 // the number of prepares that did not find the statement in the statement cache
+		StatementsPrepared   uint64
+		StatementsExecuted   uint64
+		RowsFetched          uint64
+		LOBBytesRead         uint64
+		LOBBytesWritten      uint64
+		Breaks               uint64 // the number of calls interrupted with OCIBreak because the context was done
+		BadConns             uint64 // the number of errors that made the connection unusable
+		// RoundTrips is the number of calls that can make a round trip to the server:
+		// executes, fetches, commits, rollbacks, pings, and LOB calls.
+		// Fetches of prefetched rows do not make a round trip, so this is an upper bound.
+		RoundTrips uint64
+		// Memory is the bytes of C memory currently allocated for binds and for the defines of open rows
+		Memory int64
+		// OpenConnections is the number of open connections, only set for Connector stats
+		OpenConnections int
+	}
+
+	// connectorStats are the totals of the connections of a Connector
+	connectorStats struct {
+		mutex sync.Mutex
+		stats ConnStats
+	}
+
+	// connectorHostsFailed are the times the hosts of a connector last failed with a connect error
+	connectorHostsFailed struct {
+		mutex    sync.Mutex
+		failedAt map[int]time.Time
 	}
 
 	// Tx is Oracle transaction
 	Tx struct {
-		conn *Conn
+		conn        *Conn
+		ctx         context.Context // the BeginTx context, passed to OnCommit
+		commitFlags C.ub4           // OCITransCommit flags of the commit mode
+		primary     *Conn           // the connection that routed the read-only transaction to its reader session
 	}
 
 	// Stmt is Oracle statement
@@ -89,6 +475,10 @@ type (
 		ctx         context.Context
 		cacheKey    string // if statement caching is enabled, this is the key for this statement into the cache
 		releaseMode C.ub4
+		scrollable  bool // if true, query is executed with a scrollable cursor
+		numInput    int  // number of inputs, -1 if unknown
+		queryText   string
+		logBinds    []interface{} // the bind values of the last execute for the LevelLogger, redacted unless LogBindValues
 	}
 
 	// Rows is Oracle rows
@@ -96,6 +486,111 @@ type (
 		stmt    *Stmt
 		defines []defineStruct
 		closed  bool
+
+		// used by the OnFetch hook
+		hookCtx       context.Context
+		statementType string
+		fetched       int64
+		fetchDuration time.Duration
+		fetchDone     bool
+	}
+
+	// ScrollableRows is Oracle rows from a scrollable cursor.
+	// Rows can be fetched in any order with First, Last, Prior, Absolute, and Relative.
+	ScrollableRows struct {
+		*Rows
+	}
+
+	// Description is the result of Describe
+	Description struct {
+		// Columns is the select-list columns, empty if the statement is not a query
+		Columns []ColumnDescription
+		// BindNames is the bind variable names in order of first appearance, without the leading colon
+		BindNames []string
+	}
+
+	// ColumnDescription is the metadata of a select-list column
+	ColumnDescription struct {
+		Name             string
+		DatabaseTypeName string // Oracle type name, like VARCHAR2, NUMBER, or DATE
+		DataType         int    // Oracle internal data type code
+		Size             int    // max size in bytes
+		Precision        int
+		Scale            int
+		Nullable         bool
+		CharsetForm      int // 1 is SQLCS_IMPLICIT, 2 is SQLCS_NCHAR
+		CharLength       int // declared length in characters of a character column
+	}
+
+	// ObjectDescription is the result of DescribeObject
+	ObjectDescription struct {
+		Schema string
+		Name   string
+		// Type is TABLE, VIEW, SYNONYM, SEQUENCE, PACKAGE, PROCEDURE, FUNCTION, or TYPE
+		Type string
+		// Columns is set for tables and views
+		Columns []TableColumn
+		// Arguments is set for procedures and functions. For a function the first argument is the return value.
+		Arguments []Argument
+		// Subprograms is set for packages
+		Subprograms []Subprogram
+		// Synonym is set for synonyms
+		Synonym *SynonymDescription
+		// Sequence is set for sequences
+		Sequence *SequenceDescription
+	}
+
+	// TableColumn is the metadata of a table or view column
+	TableColumn struct {
+		ColumnDescription
+		HasDefault bool   // true if the column has a default value
+		Default    string // default value expression
+		TypeSchema string // schema of an object type column
+		TypeName   string // type name of an object type column
+	}
+
+	// Argument is the metadata of a procedure or function argument
+	Argument struct {
+		Name             string
+		Position         int    // 0 is the return value of a function
+		Level            int    // 0 for top level arguments, greater than 0 for record fields and collection elements
+		Direction        string // IN, OUT, or IN/OUT
+		DatabaseTypeName string // Oracle type name, like VARCHAR2, NUMBER, or PL/SQL BOOLEAN
+		DataType         int    // Oracle internal data type code
+		Size             int
+		Precision        int
+		Scale            int
+		HasDefault       bool
+		TypeSchema       string     // schema of a named type
+		TypeName         string     // name of a named type, like an object type or a package
+		TypeSubName      string     // name of a PL/SQL type inside the package TypeName
+		Arguments        []Argument // fields of a PL/SQL record or the element of a collection
+	}
+
+	// Subprogram is the metadata of a package procedure or function
+	Subprogram struct {
+		Name       string
+		Type       string // PROCEDURE or FUNCTION
+		OverloadID int
+		Arguments  []Argument
+	}
+
+	// SynonymDescription is the object a synonym translates to
+	SynonymDescription struct {
+		Schema string
+		Name   string
+		Link   string // database link, empty if local
+	}
+
+	// SequenceDescription is the metadata of a sequence.
+	// Numbers are strings because they can be larger than int64.
+	SequenceDescription struct {
+		Min           string
+		Max           string
+		Increment     string
+		Cache         string // 0 if the sequence is not cached
+		Order         bool
+		HighWaterMark string
 	}
 
 	// Result is Oracle result
@@ -116,6 +611,7 @@ type (
 		indicator    *C.sb2
 		defineHandle *C.OCIDefine
 		subDefines   []defineStruct
+		column       ColumnDescription
 	}
 
 	bindStruct struct {
@@ -145,8 +641,59 @@ var (
 
 	// ErrNoRowid is result has no rowid
 	ErrNoRowid = errors.New("result has no rowid")
+	// ErrNotConn is connection passed in is not an oci8 Conn
+	ErrNotConn = errors.New("connection is not an oci8 Conn")
+	// ErrEventsNotEnabled is connection environment was not created with events enabled
+	ErrEventsNotEnabled = errors.New("connection was not opened with events enabled")
+	// ErrSubscriptionClosed is subscription has been closed
+	ErrSubscriptionClosed = errors.New("subscription is closed")
+	// ErrQueuePayload is message payload does not match the queue payload type
+	ErrQueuePayload = errors.New("message payload does not match the queue payload type")
+	// ErrXIDTooLong is global transaction ID or branch qualifier is longer than 64 bytes
+	ErrXIDTooLong = errors.New("global transaction ID or branch qualifier is longer than 64 bytes")
+	// ErrNotInTransaction is connection is not in a transaction
+	ErrNotInTransaction = errors.New("connection is not in a transaction")
+	// ErrSavepointName is savepoint name is not a valid unquoted identifier
+	ErrSavepointName = errors.New("savepoint name is not a valid identifier")
+	// ErrSavepointNotFound is savepoint name was not set in the current transaction
+	ErrSavepointNotFound = errors.New("savepoint was not set in the current transaction")
+	// ErrConnectDescriptorHosts is returned when a ConnectDescriptor has no Hosts
+	ErrConnectDescriptorHosts = errors.New("connect descriptor has no hosts")
+	// ErrConnectDescriptorService is returned when a ConnectDescriptor does not have one of ServiceName or SID
+	ErrConnectDescriptorService = errors.New("connect descriptor needs one of service name or SID")
+
+	// DefaultBadConnCodes are the ORA error codes that mean the connection is no longer usable.
+	// Errors with these codes are returned as driver.ErrBadConn so database/sql discards the connection.
+	//	ORA-00028: your session has been killed
+	//	ORA-01012: not logged on
+	//	ORA-01033: ORACLE initialization or shutdown in progress
+	//	ORA-01034: ORACLE not available
+	//	ORA-01089: immediate shutdown in progress - no operations are permitted
+	//	ORA-01092: ORACLE instance terminated. Disconnection forced
+	//	ORA-03113: end-of-file on communication channel
+	//	ORA-03114: not connected to ORACLE
+	//	ORA-03135: connection lost contact
+	//	ORA-03137: malformed TTC packet from client rejected
+	//	ORA-12514: TNS:listener does not currently know of service requested in connect descriptor
+	//	ORA-12528: TNS:listener: all appropriate instances are blocking new connections
+	//	ORA-12537: TNS:connection closed
+	//	ORA-12541: TNS:no listener
+	//	ORA-25408: can not safely replay call
+	DefaultBadConnCodes = []int{28, 1012, 1033, 1034, 1089, 1092, 3113, 3114, 3135, 3137, 12514, 12528, 12537, 12541, 25408}
+
+	// connectErrorCodes are the ORA error codes, in addition to DefaultBadConnCodes, of connect errors that fail over to the next host
+	//	ORA-12170: TNS:Connect timeout occurred
+	//	ORA-12535: TNS:operation timed out
+	//	ORA-12543: TNS:destination host unreachable
+	//	ORA-12545: Connect failed because target host or object does not exist
+	//	ORA-12547: TNS:lost contact
+	//	ORA-12560: TNS:protocol adapter error
+	connectErrorCodes = []int{12170, 12535, 12543, 12545, 12547, 12560}
+
+	subscriptionsMutex sync.Mutex
+	subscriptions      = make(map[C.ub8]*Subscription)
+	subscriptionNextID C.ub8
 
-	phre           = regexp.MustCompile(`\?`)
 	defaultCharset = C.ub2(0)
 
 	typeNil       = reflect.TypeOf(nil)
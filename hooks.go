@@ -0,0 +1,120 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"context"
+	"time"
+	"unsafe"
+)
+
+// statementTypeNames are the names of the OCI_ATTR_STMT_TYPE statement types
+var statementTypeNames = map[C.ub2]string{
+	C.OCI_STMT_SELECT:  "SELECT",
+	C.OCI_STMT_UPDATE:  "UPDATE",
+	C.OCI_STMT_DELETE:  "DELETE",
+	C.OCI_STMT_INSERT:  "INSERT",
+	C.OCI_STMT_CREATE:  "CREATE",
+	C.OCI_STMT_DROP:    "DROP",
+	C.OCI_STMT_ALTER:   "ALTER",
+	C.OCI_STMT_BEGIN:   "BEGIN",
+	C.OCI_STMT_DECLARE: "DECLARE",
+	C.OCI_STMT_CALL:    "CALL",
+	C.OCI_STMT_MERGE:   "MERGE",
+}
+
+// statementType returns the name of the statement type, or UNKNOWN
+func (stmt *Stmt) statementType() string {
+	var stmtType C.ub2
+	_, err := stmt.ociAttrGet(unsafe.Pointer(&stmtType), C.OCI_ATTR_STMT_TYPE)
+	if err != nil {
+		return "UNKNOWN"
+	}
+	name, ok := statementTypeNames[stmtType]
+	if !ok {
+		return "UNKNOWN"
+	}
+	return name
+}
+
+// hookPrepare calls the OnPrepare hook
+func (conn *Conn) hookPrepare(ctx context.Context, query string, start time.Time, err error) {
+	conn.hooks.OnPrepare(ctx, HookEvent{
+		Query:    query,
+		Duration: time.Since(start),
+		Err:      err,
+	})
+}
+
+// hookExecStart calls the OnExecStart hook then returns the context for OnExecEnd and OnFetch
+func (stmt *Stmt) hookExecStart(event HookEvent) context.Context {
+	if stmt.conn.hooks.OnExecStart == nil {
+		return stmt.ctx
+	}
+	ctx := stmt.conn.hooks.OnExecStart(stmt.ctx, event)
+	if ctx == nil {
+		return stmt.ctx
+	}
+	return ctx
+}
+
+// hookFetchDone calls the OnFetch hook once
+func (rows *Rows) hookFetchDone(err error) {
+	if rows.fetchDone || rows.hookCtx == nil {
+		return
+	}
+	rows.fetchDone = true
+	rows.stmt.conn.hooks.OnFetch(rows.hookCtx, HookEvent{
+		Query:         rows.stmt.queryText,
+		StatementType: rows.statementType,
+		RowsFetched:   rows.fetched,
+		Duration:      rows.fetchDuration,
+		Err:           err,
+	})
+}
+
+// hookCommit calls the OnCommit hook
+func (tx *Tx) hookCommit(rollback bool, start time.Time, err error) {
+	ctx := tx.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	tx.conn.hooks.OnCommit(ctx, HookEvent{
+		Rollback: rollback,
+		Duration: time.Since(start),
+		Err:      err,
+	})
+}
+
+// hookExec calls the OnExecStart hook then returns the function to call with the result of the execute,
+// which calls the OnExecEnd hook and sets up the OnFetch hook of the rows of a query
+func (stmt *Stmt) hookExec(numBinds int) func(result interface{}, err error) {
+	event := HookEvent{
+		Query:         stmt.queryText,
+		StatementType: stmt.statementType(),
+		NumBinds:      numBinds,
+	}
+	hookCtx := stmt.hookExecStart(event)
+	start := time.Now()
+
+	return func(result interface{}, err error) {
+		switch result := result.(type) {
+		case *Result:
+			if result != nil {
+				event.RowsAffected = result.rowsAffected
+			}
+		case *Rows:
+			if result != nil && stmt.conn.hooks.OnFetch != nil {
+				result.hookCtx = hookCtx
+				result.statementType = event.StatementType
+			}
+		}
+
+		if stmt.conn.hooks.OnExecEnd != nil {
+			event.Duration = time.Since(start)
+			event.Err = err
+			stmt.conn.hooks.OnExecEnd(hookCtx, event)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package oci8
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logError logs err to the Logger and as an error to the LevelLogger
+func (conn *Conn) logError(ctx context.Context, msg string, err error) {
+	conn.logger.Print(msg+": ", err)
+	if conn.levelLogger == nil {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	conn.levelLogger.ErrorContext(ctx, "oci8 "+msg, "code", oraCode(err), "error", err)
+}
+
+// logStatement returns the function to call with the result of the execute of the statement,
+// which logs the statement at debug level, errors at error level,
+// and statements slower than the slow statement threshold at warn level
+func (stmt *Stmt) logStatement(msg string) func(err error) {
+	start := time.Now()
+
+	return func(err error) {
+		ctx := stmt.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		duration := time.Since(start)
+		logger := stmt.conn.levelLogger
+
+		logger.DebugContext(ctx, "oci8 "+msg, "query", stmt.queryText, "binds", stmt.logBinds, "duration", duration)
+
+		if err != nil {
+			logger.ErrorContext(ctx, "oci8 "+msg+" error", "query", stmt.queryText, "binds", stmt.logBinds, "code", oraCode(err), "error", err)
+		}
+
+		if stmt.conn.slowStatementThreshold > 0 && duration >= stmt.conn.slowStatementThreshold {
+			logger.WarnContext(ctx, "oci8 slow "+msg, "query", stmt.queryText, "binds", stmt.logBinds, "duration", duration)
+		}
+	}
+}
+
+// logSuccessWithInfo logs the warning of an OCI_SUCCESS_WITH_INFO execute at warn level.
+// It must be called right after the execute, while the warning is in the error handle.
+func (stmt *Stmt) logSuccessWithInfo(msg string) {
+	ctx := stmt.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_, info := stmt.conn.ociGetError()
+	stmt.conn.levelLogger.WarnContext(ctx, "oci8 "+msg+" success with info", "query", stmt.queryText, "code", oraCode(info), "info", info)
+}
+
+// logBindValues returns the bind values to log, which are redacted unless LogBindValues is set
+func (conn *Conn) logBindValues(values []driver.Value, namedValues []driver.NamedValue) []interface{} {
+	count := len(namedValues)
+	if count == 0 {
+		count = len(values)
+	}
+	if count == 0 {
+		return nil
+	}
+
+	logBinds := make([]interface{}, count)
+	for i := 0; i < count; i++ {
+		var value interface{}
+		if len(namedValues) > 0 {
+			value = namedValues[i].Value
+		} else {
+			value = values[i]
+		}
+		if !conn.logBinds {
+			value = redactBindValue(value)
+		}
+		if len(namedValues) > 0 && namedValues[i].Name != "" {
+			value = fmt.Sprintf("%v=%v", namedValues[i].Name, value)
+		}
+		logBinds[i] = value
+	}
+	return logBinds
+}
+
+// redactBindValue returns the placeholder that replaces a bind value in the log, which only shows the type of the value
+func redactBindValue(value interface{}) string {
+	if value == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("<redacted %T>", value)
+}
+
+// oraCode returns the ORA error code of the error text, or 0 if it does not start with one
+func oraCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	text := err.Error()
+	if !strings.HasPrefix(text, "ORA-") {
+		return 0
+	}
+	text = text[4:]
+	end := 0
+	for end < len(text) && text[end] >= '0' && text[end] <= '9' {
+		end++
+	}
+	code, _ := strconv.Atoi(text[:end])
+	return code
+}
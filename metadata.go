@@ -0,0 +1,694 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"unsafe"
+)
+
+// DescribeObject describes a schema object with OCIDescribeAny.
+// The name can be qualified by schema, like SCOTT.EMP, and by database link, like EMP@REMOTE.
+// The column defaults of a table are read from ALL_TAB_COLUMNS of the database of the database link.
+// Tables, views, synonyms, sequences, packages, procedures, and functions are described in detail,
+// other objects only have Schema, Name, and Type set.
+func DescribeObject(ctx context.Context, conn driver.Conn, name string) (*ObjectDescription, error) {
+	oci8Conn, err := toConn(conn)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	describeHandle, param, err := oci8Conn.ociDescribeAny(ctx, name, C.OCI_PTYPE_UNK)
+	if err != nil {
+		return nil, err
+	}
+	defer C.OCIHandleFree(unsafe.Pointer(describeHandle), C.OCI_HTYPE_DESCRIBE)
+
+	var objectType C.ub1
+	_, err = oci8Conn.ociAttrGet(param, unsafe.Pointer(&objectType), C.OCI_ATTR_PTYPE)
+	if err != nil {
+		return nil, err
+	}
+
+	object := &ObjectDescription{}
+	object.Schema, err = oci8Conn.ociAttrGetString(param, C.OCI_ATTR_OBJ_SCHEMA)
+	if err != nil {
+		return nil, err
+	}
+	object.Name, err = oci8Conn.ociAttrGetString(param, C.OCI_ATTR_OBJ_NAME)
+	if err != nil {
+		return nil, err
+	}
+
+	switch objectType {
+	case C.OCI_PTYPE_TABLE:
+		object.Type = "TABLE"
+		object.Columns, err = oci8Conn.describeTableColumns(param)
+		if err != nil {
+			return nil, err
+		}
+		var link string
+		link, err = databaseLink(name)
+		if err != nil {
+			return nil, err
+		}
+		err = oci8Conn.describeColumnDefaults(ctx, object, link)
+	case C.OCI_PTYPE_VIEW:
+		object.Type = "VIEW"
+		object.Columns, err = oci8Conn.describeTableColumns(param)
+	case C.OCI_PTYPE_PROC:
+		object.Type = "PROCEDURE"
+		object.Arguments, err = oci8Conn.describeArguments(param, false)
+	case C.OCI_PTYPE_FUNC:
+		object.Type = "FUNCTION"
+		object.Arguments, err = oci8Conn.describeArguments(param, true)
+	case C.OCI_PTYPE_PKG:
+		object.Type = "PACKAGE"
+		object.Subprograms, err = oci8Conn.describeSubprograms(param)
+	case C.OCI_PTYPE_SYN:
+		object.Type = "SYNONYM"
+		object.Synonym, err = oci8Conn.describeSynonym(param)
+	case C.OCI_PTYPE_SEQ:
+		object.Type = "SEQUENCE"
+		object.Sequence, err = oci8Conn.describeSequence(param)
+	case C.OCI_PTYPE_TYPE:
+		object.Type = "TYPE"
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return object, nil
+}
+
+// ociDescribeAny calls OCIDescribeAny on the object name then returns the describe handle and the object parameter descriptor.
+// The describe handle must be freed, the parameter descriptor is freed with the describe handle.
+func (conn *Conn) ociDescribeAny(ctx context.Context, name string, objectType C.ub1) (*C.OCIDescribe, *C.OCIParam, error) {
+	handle, _, err := conn.ociHandleAlloc(C.OCI_HTYPE_DESCRIBE, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	describeHandle := (*C.OCIDescribe)(*handle)
+
+	nameP := cString(name)
+	defer C.free(unsafe.Pointer(nameP))
+
+	done := conn.ociBreakOnDone(ctx)
+	result := C.OCIDescribeAny(
+		conn.svc,              // service context handle
+		conn.errHandle,        // error handle
+		unsafe.Pointer(nameP), // the name of the object to be described
+		C.ub4(len(name)),      // length of the name
+		C.OCI_OTYPE_NAME,      // object is specified by name
+		C.OCI_DEFAULT,         // info level, must be OCI_DEFAULT
+		objectType,            // type of object to be described, OCI_PTYPE_UNK means any type
+		describeHandle,        // describe handle that is populated with describe information
+	)
+	closeDone(done)
+	if result != C.OCI_SUCCESS {
+		C.OCIHandleFree(unsafe.Pointer(describeHandle), C.OCI_HTYPE_DESCRIBE)
+		return nil, nil, conn.getError(result)
+	}
+
+	var param *C.OCIParam
+	_, err = conn.ociAttrGetHandle(unsafe.Pointer(describeHandle), C.OCI_HTYPE_DESCRIBE, unsafe.Pointer(&param), C.OCI_ATTR_PARAM)
+	if err != nil {
+		C.OCIHandleFree(unsafe.Pointer(describeHandle), C.OCI_HTYPE_DESCRIBE)
+		return nil, nil, err
+	}
+
+	return describeHandle, param, nil
+}
+
+// describeTableColumns returns the columns of a table or view parameter descriptor
+func (conn *Conn) describeTableColumns(param *C.OCIParam) ([]TableColumn, error) {
+	var numColumns C.ub2
+	_, err := conn.ociAttrGet(param, unsafe.Pointer(&numColumns), C.OCI_ATTR_NUM_COLS)
+	if err != nil {
+		return nil, err
+	}
+
+	var list *C.OCIParam
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&list), C.OCI_ATTR_LIST_COLUMNS)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]TableColumn, int(numColumns))
+	for i := 0; i < len(columns); i++ {
+		var columnParam *C.OCIParam
+		columnParam, err = conn.ociParamGetList(list, C.ub4(i+1))
+		if err != nil {
+			return nil, err
+		}
+		columns[i], err = conn.describeTableColumn(columnParam)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return columns, nil
+}
+
+// describeTableColumn returns the column metadata of an explicit describe column parameter descriptor
+func (conn *Conn) describeTableColumn(param *C.OCIParam) (TableColumn, error) {
+	var column TableColumn
+	var err error
+
+	column.Name, err = conn.ociAttrGetString(param, C.OCI_ATTR_NAME)
+	if err != nil {
+		return column, err
+	}
+
+	var dataType C.ub2 // internal data type of the column
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&dataType), C.OCI_ATTR_DATA_TYPE)
+	if err != nil {
+		return column, err
+	}
+
+	var dataSize C.ub2 // max size in bytes of the column
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&dataSize), C.OCI_ATTR_DATA_SIZE)
+	if err != nil {
+		return column, err
+	}
+
+	var precision C.ub1 // the precision, for an explicit describe it is ub1
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&precision), C.OCI_ATTR_PRECISION)
+	if err != nil {
+		return column, err
+	}
+
+	var scale C.sb1 // the scale (number of digits to the right of the decimal point)
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&scale), C.OCI_ATTR_SCALE)
+	if err != nil {
+		return column, err
+	}
+
+	var isNull C.ub1 // 0 if null values are not permitted for the column
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&isNull), C.OCI_ATTR_IS_NULL)
+	if err != nil {
+		return column, err
+	}
+
+	var charsetForm C.ub1 // character set form: SQLCS_IMPLICIT or SQLCS_NCHAR, 0 for non character columns
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&charsetForm), C.OCI_ATTR_CHARSET_FORM)
+	if err != nil {
+		return column, err
+	}
+
+	var charSize C.ub2 // declared length in characters of a character column
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&charSize), C.OCI_ATTR_CHAR_SIZE)
+	if err != nil {
+		return column, err
+	}
+
+	if dataType == C.SQLT_NTY || dataType == C.SQLT_REF {
+		column.TypeSchema, err = conn.ociAttrGetString(param, C.OCI_ATTR_SCHEMA_NAME)
+		if err != nil {
+			return column, err
+		}
+		column.TypeName, err = conn.ociAttrGetString(param, C.OCI_ATTR_TYPE_NAME)
+		if err != nil {
+			return column, err
+		}
+	}
+
+	column.DatabaseTypeName = oracleTypeName(dataType, charsetForm, C.sb2(precision), scale)
+	column.DataType = int(dataType)
+	column.Size = int(dataSize)
+	column.Precision = int(precision)
+	column.Scale = int(scale)
+	column.Nullable = isNull != 0
+	column.CharsetForm = int(charsetForm)
+	column.CharLength = int(charSize)
+
+	return column, nil
+}
+
+// describeColumnDefaults sets the column defaults of a table from ALL_TAB_COLUMNS, of the database link if not empty,
+// OCIDescribeAny does not return column defaults
+func (conn *Conn) describeColumnDefaults(ctx context.Context, object *ObjectDescription, link string) error {
+	view := "all_tab_columns"
+	if link != "" {
+		view += "@" + link
+	}
+	stmt, err := conn.prepareRaw(ctx, "select column_name, data_default from "+view+" where owner = :1 and table_name = :2")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	driverRows, err := stmt.QueryContext(ctx, []driver.NamedValue{{Ordinal: 1, Value: object.Schema}, {Ordinal: 2, Value: object.Name}})
+	if err != nil {
+		return err
+	}
+	rows := driverRows.(*Rows)
+	defer rows.Close()
+
+	defaults := make(map[string]string, len(object.Columns))
+	dest := make([]driver.Value, 2)
+	for {
+		err = rows.Next(dest)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if dest[1] != nil {
+			defaults[dest[0].(string)] = strings.TrimSpace(dest[1].(string))
+		}
+	}
+
+	for i := range object.Columns {
+		object.Columns[i].Default, object.Columns[i].HasDefault = defaults[object.Columns[i].Name]
+	}
+
+	return nil
+}
+
+// databaseLink returns the database link of an object name like EMP@REMOTE, or empty if the name has none.
+// The database link can have a domain and a connection qualifier, like REMOTE.EXAMPLE.COM@HR, and quoted parts.
+func databaseLink(name string) (string, error) {
+	i := strings.IndexByte(name, '@')
+	if i < 0 {
+		return "", nil
+	}
+	link := name[i+1:]
+	if link == "" || strings.Count(link, `"`)%2 != 0 {
+		return "", fmt.Errorf("invalid database link: %v", link)
+	}
+	for j := 0; j < len(link); j++ {
+		c := link[j]
+		if !isIdentifierByte(c) && c != '.' && c != '@' && c != '"' {
+			return "", fmt.Errorf("invalid database link: %v", link)
+		}
+	}
+	return link, nil
+}
+
+// describeArguments returns the arguments of a procedure or function parameter descriptor
+func (conn *Conn) describeArguments(param *C.OCIParam, isFunction bool) ([]Argument, error) {
+	var list *C.OCIParam
+	_, err := conn.ociAttrGet(param, unsafe.Pointer(&list), C.OCI_ATTR_LIST_ARGUMENTS)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		return nil, nil
+	}
+
+	var numArguments C.ub2
+	_, err = conn.ociAttrGet(list, unsafe.Pointer(&numArguments), C.OCI_ATTR_NUM_PARAMS)
+	if err != nil {
+		return nil, err
+	}
+
+	// for a function position 0 is the return value, for a procedure positions start at 1
+	start := 1
+	if isFunction {
+		start = 0
+	}
+
+	arguments := make([]Argument, 0, int(numArguments))
+	for i := start; i < int(numArguments)+start; i++ {
+		var argumentParam *C.OCIParam
+		argumentParam, err = conn.ociParamGetList(list, C.ub4(i))
+		if err != nil {
+			return nil, err
+		}
+		var argument Argument
+		argument, err = conn.describeArgument(argumentParam)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+	}
+
+	return arguments, nil
+}
+
+// describeArgument returns the argument metadata of an argument parameter descriptor
+func (conn *Conn) describeArgument(param *C.OCIParam) (Argument, error) {
+	var argument Argument
+	var err error
+
+	argument.Name, err = conn.ociAttrGetString(param, C.OCI_ATTR_NAME)
+	if err != nil {
+		return argument, err
+	}
+
+	var position C.ub2
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&position), C.OCI_ATTR_POSITION)
+	if err != nil {
+		return argument, err
+	}
+
+	var level C.ub2
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&level), C.OCI_ATTR_LEVEL)
+	if err != nil {
+		return argument, err
+	}
+
+	var dataType C.ub2
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&dataType), C.OCI_ATTR_DATA_TYPE)
+	if err != nil {
+		return argument, err
+	}
+
+	var dataSize C.ub2
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&dataSize), C.OCI_ATTR_DATA_SIZE)
+	if err != nil {
+		return argument, err
+	}
+
+	var precision C.ub1
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&precision), C.OCI_ATTR_PRECISION)
+	if err != nil {
+		return argument, err
+	}
+
+	var scale C.sb1
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&scale), C.OCI_ATTR_SCALE)
+	if err != nil {
+		return argument, err
+	}
+
+	var hasDefault C.ub1
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&hasDefault), C.OCI_ATTR_HAS_DEFAULT)
+	if err != nil {
+		return argument, err
+	}
+
+	var ioMode C.ub4 // OCITypeParamMode
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&ioMode), C.OCI_ATTR_IOMODE)
+	if err != nil {
+		return argument, err
+	}
+
+	var charsetForm C.ub1
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&charsetForm), C.OCI_ATTR_CHARSET_FORM)
+	if err != nil {
+		return argument, err
+	}
+
+	argument.TypeSchema, err = conn.ociAttrGetString(param, C.OCI_ATTR_SCHEMA_NAME)
+	if err != nil {
+		return argument, err
+	}
+	argument.TypeName, err = conn.ociAttrGetString(param, C.OCI_ATTR_TYPE_NAME)
+	if err != nil {
+		return argument, err
+	}
+	argument.TypeSubName, err = conn.ociAttrGetString(param, C.OCI_ATTR_SUB_NAME)
+	if err != nil {
+		return argument, err
+	}
+
+	switch ioMode {
+	case C.OCI_TYPEPARAM_OUT:
+		argument.Direction = "OUT"
+	case C.OCI_TYPEPARAM_INOUT:
+		argument.Direction = "IN/OUT"
+	default:
+		argument.Direction = "IN"
+	}
+
+	argument.Position = int(position)
+	argument.Level = int(level)
+	argument.DatabaseTypeName = plsqlTypeName(dataType, charsetForm, C.sb2(precision), scale)
+	argument.DataType = int(dataType)
+	argument.Size = int(dataSize)
+	argument.Precision = int(precision)
+	argument.Scale = int(scale)
+	argument.HasDefault = hasDefault != 0
+
+	switch dataType {
+	case 250, 251, 122: // PL/SQL record, PL/SQL index-by table, and collection have sub arguments
+		argument.Arguments, err = conn.describeArguments(param, false)
+		if err != nil {
+			return argument, err
+		}
+	}
+
+	return argument, nil
+}
+
+// plsqlTypeName returns the PL/SQL type name of an internal data type
+func plsqlTypeName(dataType C.ub2, charsetForm C.ub1, precision C.sb2, scale C.sb1) string {
+	switch dataType {
+	case C.SQLT_INT:
+		return "BINARY_INTEGER"
+	case 102: // SQLT_CUR
+		return "REF CURSOR"
+	case 122: // SQLT_NCO
+		return "COLLECTION"
+	case 250: // SQLT_REC
+		return "PL/SQL RECORD"
+	case 251: // SQLT_TAB
+		return "PL/SQL TABLE"
+	case 252: // SQLT_BOL
+		return "PL/SQL BOOLEAN"
+	}
+	return oracleTypeName(dataType, charsetForm, precision, scale)
+}
+
+// describeSubprograms returns the subprograms of a package parameter descriptor
+func (conn *Conn) describeSubprograms(param *C.OCIParam) ([]Subprogram, error) {
+	var list *C.OCIParam
+	_, err := conn.ociAttrGet(param, unsafe.Pointer(&list), C.OCI_ATTR_LIST_SUBPROGRAMS)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		return nil, nil
+	}
+
+	var numSubprograms C.ub2
+	_, err = conn.ociAttrGet(list, unsafe.Pointer(&numSubprograms), C.OCI_ATTR_NUM_PARAMS)
+	if err != nil {
+		return nil, err
+	}
+
+	subprograms := make([]Subprogram, int(numSubprograms))
+	for i := 0; i < len(subprograms); i++ {
+		var subprogramParam *C.OCIParam
+		subprogramParam, err = conn.ociParamGetList(list, C.ub4(i))
+		if err != nil {
+			return nil, err
+		}
+
+		subprograms[i].Name, err = conn.ociAttrGetString(subprogramParam, C.OCI_ATTR_NAME)
+		if err != nil {
+			return nil, err
+		}
+
+		var overloadID C.ub2
+		_, err = conn.ociAttrGet(subprogramParam, unsafe.Pointer(&overloadID), C.OCI_ATTR_OVERLOAD_ID)
+		if err != nil {
+			return nil, err
+		}
+		subprograms[i].OverloadID = int(overloadID)
+
+		var subprogramType C.ub1
+		_, err = conn.ociAttrGet(subprogramParam, unsafe.Pointer(&subprogramType), C.OCI_ATTR_PTYPE)
+		if err != nil {
+			return nil, err
+		}
+
+		isFunction := subprogramType == C.OCI_PTYPE_FUNC
+		if isFunction {
+			subprograms[i].Type = "FUNCTION"
+		} else {
+			subprograms[i].Type = "PROCEDURE"
+		}
+
+		subprograms[i].Arguments, err = conn.describeArguments(subprogramParam, isFunction)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return subprograms, nil
+}
+
+// describeSynonym returns the translated object of a synonym parameter descriptor
+func (conn *Conn) describeSynonym(param *C.OCIParam) (*SynonymDescription, error) {
+	var err error
+	synonym := &SynonymDescription{}
+
+	synonym.Schema, err = conn.ociAttrGetString(param, C.OCI_ATTR_SCHEMA_NAME)
+	if err != nil {
+		return nil, err
+	}
+	synonym.Name, err = conn.ociAttrGetString(param, C.OCI_ATTR_NAME)
+	if err != nil {
+		return nil, err
+	}
+	synonym.Link, err = conn.ociAttrGetString(param, C.OCI_ATTR_LINK)
+	if err != nil {
+		return nil, err
+	}
+
+	return synonym, nil
+}
+
+// describeSequence returns the metadata of a sequence parameter descriptor
+func (conn *Conn) describeSequence(param *C.OCIParam) (*SequenceDescription, error) {
+	var err error
+	sequence := &SequenceDescription{}
+
+	sequence.Min, err = conn.ociAttrGetNumber(param, C.OCI_ATTR_MIN)
+	if err != nil {
+		return nil, err
+	}
+	sequence.Max, err = conn.ociAttrGetNumber(param, C.OCI_ATTR_MAX)
+	if err != nil {
+		return nil, err
+	}
+	sequence.Increment, err = conn.ociAttrGetNumber(param, C.OCI_ATTR_INCR)
+	if err != nil {
+		return nil, err
+	}
+	sequence.Cache, err = conn.ociAttrGetNumber(param, C.OCI_ATTR_CACHE)
+	if err != nil {
+		return nil, err
+	}
+	sequence.HighWaterMark, err = conn.ociAttrGetNumber(param, C.OCI_ATTR_HW_MARK)
+	if err != nil {
+		return nil, err
+	}
+
+	var order C.ub1
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&order), C.OCI_ATTR_ORDER)
+	if err != nil {
+		return nil, err
+	}
+	sequence.Order = order != 0
+
+	return sequence, nil
+}
+
+// ociParamGetList calls OCIParamGet on a list parameter descriptor then returns the OCIParam at position.
+// The returned OCIParam is freed with the describe handle.
+func (conn *Conn) ociParamGetList(list *C.OCIParam, position C.ub4) (*C.OCIParam, error) {
+	var param *C.OCIParam
+
+	result := C.OCIParamGet(
+		unsafe.Pointer(list), // A describe list parameter descriptor
+		C.OCI_DTYPE_PARAM,    // Handle type: OCI_DTYPE_PARAM, for a parameter descriptor
+		conn.errHandle,       // An error handle
+		(*unsafe.Pointer)(unsafe.Pointer(&param)), // A descriptor of the parameter at the position
+		position, // Position number in the list
+	)
+
+	err := conn.getError(result)
+	if err != nil {
+		return nil, err
+	}
+
+	return param, nil
+}
+
+// ociAttrGetString calls OCIAttrGet with OCIParam then returns the text attribute as a string
+func (conn *Conn) ociAttrGetString(param *C.OCIParam, attributeType C.ub4) (string, error) {
+	var text *C.OraText
+	size, err := conn.ociAttrGet(param, unsafe.Pointer(&text), attributeType)
+	if err != nil {
+		return "", err
+	}
+	if text == nil {
+		return "", nil
+	}
+	return cGoStringN(text, int(size)), nil
+}
+
+// ociAttrGetNumber calls OCIAttrGet with OCIParam then returns the Oracle NUMBER attribute as a string
+func (conn *Conn) ociAttrGetNumber(param *C.OCIParam, attributeType C.ub4) (string, error) {
+	var number *C.ub1
+	size, err := conn.ociAttrGet(param, unsafe.Pointer(&number), attributeType)
+	if err != nil {
+		return "", err
+	}
+	if number == nil || size == 0 {
+		return "", nil
+	}
+	return oracleNumberToString(C.GoBytes(unsafe.Pointer(number), C.int(size))), nil
+}
+
+// oracleNumberToString converts the Oracle NUMBER internal format to a decimal string.
+// The first byte is the sign bit and the base 100 exponent, then each byte is a base 100 digit.
+// https://docs.oracle.com/en/database/oracle/oracle-database/12.2/lnoci/data-types.html#GUID-BDB3926E-2C3E-4BEA-A0F2-AA67B6A8E0A1
+func oracleNumberToString(number []byte) string {
+	if len(number) == 0 {
+		return ""
+	}
+
+	positive := number[0]&0x80 != 0
+	switch {
+	case positive && len(number) == 1:
+		return "0"
+	case positive && number[0] == 0xff && len(number) == 2 && number[1] == 101:
+		return "Inf"
+	case !positive && number[0] == 0 && (len(number) == 1 || (len(number) == 2 && number[1] == 102)):
+		return "-Inf"
+	}
+
+	digits := number[1:]
+	var exponent int
+	if positive {
+		exponent = int(number[0]&0x7f) - 65
+	} else {
+		exponent = int(^number[0]&0x7f) - 65
+		// negative numbers shorter than 21 digits end with 102
+		if len(digits) > 0 && digits[len(digits)-1] == 102 {
+			digits = digits[:len(digits)-1]
+		}
+	}
+
+	decimal := make([]byte, 0, 2*len(digits))
+	for _, digit := range digits {
+		value := int(digit) - 1
+		if !positive {
+			value = 101 - int(digit)
+		}
+		decimal = append(decimal, byte('0'+value/10), byte('0'+value%10))
+	}
+
+	// number of decimal digits before the decimal point
+	point := 2 * (exponent + 1)
+	var integer, fraction string
+	switch {
+	case point <= 0:
+		integer = "0"
+		fraction = strings.Repeat("0", -point) + string(decimal)
+	case point >= len(decimal):
+		integer = string(decimal) + strings.Repeat("0", point-len(decimal))
+	default:
+		integer = string(decimal[:point])
+		fraction = string(decimal[point:])
+	}
+
+	integer = strings.TrimLeft(integer, "0")
+	if integer == "" {
+		integer = "0"
+	}
+	fraction = strings.TrimRight(fraction, "0")
+
+	text := integer
+	if fraction != "" {
+		text += "." + fraction
+	}
+	if !positive {
+		text = "-" + text
+	}
+	return text
+}
@@ -4,6 +4,7 @@ package oci8
 import "C"
 
 import (
+	"context"
 	"database/sql/driver"
 	"errors"
 	"fmt"
@@ -14,6 +15,8 @@ import (
 	"strings"
 	"time"
 	"unsafe"
+
+	"github.com/mattn/go-oci8/tnsnames"
 )
 
 // ParseDSN parses a DSN used to connect to Oracle
@@ -22,7 +25,11 @@ import (
 //
 // [username/[password]@]host[:port][/service_name][?param1=value1&...&paramN=valueN]
 //
-// Connection timeout can be set in the Oracle files: sqlnet.ora as SQLNET.OUTBOUND_CONNECT_TIMEOUT or tnsnames.ora as CONNECT_TIMEOUT
+// For proxy authentication, the username can be proxy_user[target_user]: proxy_user, the middle-tier account,
+// authenticates with its own password, then the session runs as target_user, the client user, without its password.
+// This needs: alter user target_user grant connect through proxy_user
+//
+// Connection timeout can be set with the connect_timeout parameter, or in the Oracle files: sqlnet.ora as SQLNET.OUTBOUND_CONNECT_TIMEOUT or tnsnames.ora as CONNECT_TIMEOUT
 //
 // Supported parameters are:
 //
@@ -36,6 +43,49 @@ import (
 // prefetch_memory - the max memory for top level rows to be prefetched. Defaults to 65536. A 0 means unlimited memory.
 //
 // questionph - when true, enables question mark placeholders. Defaults to false. (uses strconv.ParseBool to check for true)
+//
+// events - when true, creates the environment in OCI_EVENTS mode, which is needed for Subscribe. Defaults to false.
+//
+// autocommit - when false, statements outside BeginTx are not committed, the transaction must be ended with a commit or rollback statement.
+// Defaults to true. (uses strconv.ParseBool to check for true)
+//
+// reset_packages - when true, ResetSession calls DBMS_SESSION.RESET_PACKAGE to clear the package state of the session
+// before the connection is reused. Defaults to false. (uses strconv.ParseBool to check for true)
+//
+// commit_mode - how commits wait for the redo to be written: DEFAULT, NOWAIT, BATCH, or BATCH_NOWAIT. Defaults to DEFAULT.
+// Can be overridden per transaction with WithCommitMode.
+//
+// call_timeout - the max time of each round trip to the database, like 30s or 500ms. Defaults to 0, no timeout.
+// The deadline of the context of a call lowers the timeout of its round trips. A call that times out returns ORA-03156.
+//
+// send_timeout - the max time to send a request to the database, like 10s. Defaults to 0, no timeout.
+//
+// receive_timeout - the max time to wait for a response from the database, like 10s. Defaults to 0, no timeout.
+//
+// connect_timeout - the max time to connect to each address of the database, like 10s. Defaults to 0, no timeout.
+// It is added to the connect string as CONNECT_TIMEOUT of a connect descriptor,
+// or as the Easy Connect Plus connect_timeout parameter, which needs a 19c or later client.
+// The deadline of the context of Connector Connect also lowers the timeout of a connect descriptor.
+//
+// tnsnames - the path of a tnsnames.ora file, or of the directory of it, to resolve the host as a net service name alias.
+// The alias is replaced by its connect descriptor, so an unknown alias is an error of ParseDSN instead of ORA-12154 when connecting.
+// Without tnsnames, an alias is resolved by the Oracle client with TNS_ADMIN.
+//
+// Instead of the host, a DESCRIPTION connect descriptor can be built from these parameters, see ConnectDescriptor:
+// hosts - comma separated host names or IP addresses, each with an optional :port, like db1,db2:1522
+// port - the port of the hosts without one. Defaults to 1521.
+// service_name or sid - the database service name or SID
+// protocol - TCP or TCPS. Defaults to TCP.
+// wallet_location - the directory of the wallet used with TCPS
+// ssl_server_dn_match - when true, checks the distinguished name of the server certificate
+// ssl_server_cert_dn - the distinguished name the server certificate must have
+// retry_count - the number of times the hosts are tried again when the connect fails
+// retry_delay - the delay between retries, like 3s
+// sdu - the session data unit size in bytes
+// expire_time - the interval of the dead connection detection probes, like 10m
+// pool_connection_class - the Database Resident Connection Pooling connection class
+// The connect_timeout parameter, lowered by the deadline of the context, is added to the connect descriptor as CONNECT_TIMEOUT when connecting.
+// For example: scott/tiger@?hosts=db1,db2&service_name=orcl&protocol=tcps&wallet_location=/opt/wallet
 func ParseDSN(dsnString string) (dsn *DSN, err error) {
 
 	if dsnString == "" {
@@ -63,6 +113,10 @@ func ParseDSN(dsnString string) (dsn *DSN, err error) {
 		if err != nil {
 			return nil, err
 		}
+		dsn.Username, dsn.targetUser, err = splitTargetUser(dsn.Username)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	host, params := splitRight(dsnString, "?")
@@ -77,8 +131,20 @@ func ParseDSN(dsnString string) (dsn *DSN, err error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid dsn parameters: %v", err)
 	}
+	var tnsnamesPath string
+	var descriptor ConnectDescriptor
+	var useDescriptor bool
 	for k, v := range qp {
 		switch k {
+		case "tnsnames":
+			tnsnamesPath = v[0]
+		case "hosts", "port", "service_name", "sid", "protocol", "wallet_location", "ssl_server_dn_match", "ssl_server_cert_dn",
+			"retry_count", "retry_delay", "sdu", "expire_time", "pool_connection_class":
+			err = descriptor.setParameter(k, v[0])
+			if err != nil {
+				return nil, err
+			}
+			useDescriptor = true
 		case "loc":
 			if len(v) > 0 {
 				if dsn.timeLocation, err = time.LoadLocation(v[0]); err != nil {
@@ -101,6 +167,30 @@ func ParseDSN(dsnString string) (dsn *DSN, err error) {
 			if err != nil {
 				return nil, fmt.Errorf("Invalid questionph: %v", v[0])
 			}
+		case "events":
+			dsn.enableEvents, err = strconv.ParseBool(v[0])
+			if err != nil {
+				return nil, fmt.Errorf("Invalid events: %v", v[0])
+			}
+		case "autocommit":
+			var autocommit bool
+			autocommit, err = strconv.ParseBool(v[0])
+			if err != nil {
+				return nil, fmt.Errorf("Invalid autocommit: %v", v[0])
+			}
+			dsn.disableAutocommit = !autocommit
+		case "reset_packages":
+			dsn.resetPackages, err = strconv.ParseBool(v[0])
+			if err != nil {
+				return nil, fmt.Errorf("Invalid reset_packages: %v", v[0])
+			}
+		case "commit_mode":
+			var mode CommitMode
+			mode, err = parseCommitMode(v[0])
+			if err != nil {
+				return nil, err
+			}
+			dsn.commitFlags = mode.commitFlags()
 		case "prefetch_rows":
 			z, err := strconv.ParseUint(v[0], 10, 32)
 			if err != nil {
@@ -130,34 +220,116 @@ func ParseDSN(dsnString string) (dsn *DSN, err error) {
 				return nil, fmt.Errorf("invalid stmt_cache_size: %v", v[0])
 			}
 			dsn.stmtCacheSize = C.ub4(z)
+		case "call_timeout":
+			dsn.callTimeout, err = parseTimeout("call_timeout", v[0])
+			if err != nil {
+				return nil, err
+			}
+		case "send_timeout":
+			dsn.sendTimeout, err = parseTimeout("send_timeout", v[0])
+			if err != nil {
+				return nil, err
+			}
+		case "receive_timeout":
+			dsn.receiveTimeout, err = parseTimeout("receive_timeout", v[0])
+			if err != nil {
+				return nil, err
+			}
+		case "connect_timeout":
+			dsn.connectTimeout, err = time.ParseDuration(v[0])
+			if err != nil || dsn.connectTimeout < 0 {
+				return nil, fmt.Errorf("invalid connect_timeout: %v", v[0])
+			}
+		}
+	}
+
+	if useDescriptor {
+		if dsn.Connect != "" {
+			return nil, errors.New("invalid dsn: host can not be used with hosts")
+		}
+		dsn.Connect, err = descriptor.Description()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if tnsnamesPath != "" {
+		var file *tnsnames.File
+		file, err = tnsnames.ParseFile(tnsnamesPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tnsnames: %v", err)
+		}
+		dsn.Connect, err = file.Resolve(dsn.Connect)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	return dsn, nil
 }
 
-// Commit transaction commit
-func (tx *Tx) Commit() error {
-	tx.conn.inTransaction = false
-	if rv := C.OCITransCommit(
-		tx.conn.svc,
-		tx.conn.errHandle,
-		0,
-	); rv != C.OCI_SUCCESS {
-		return tx.conn.getError(rv)
+// Commit transaction commit.
+// If the commit fails with the transaction still in progress, the transaction is rolled back
+// so a later statement outside a transaction does not commit its changes.
+func (tx *Tx) Commit() (err error) {
+	conn := tx.conn
+	if tx.primary != nil {
+		// the read-only transaction ran on the reader session of the primary connection
+		tx.primary.readerTx = false
+	}
+	if conn.hooks != nil && conn.hooks.OnCommit != nil {
+		start := time.Now()
+		defer func() {
+			tx.hookCommit(false, start, err)
+		}()
+	}
+	if conn.badConn {
+		conn.inTransaction = false
+		return driver.ErrBadConn
+	}
+	conn.setCallTimeout(tx.ctx)
+	err = conn.ociTransCommit(tx.commitFlags)
+	conn.inTransaction = false
+	conn.savepoints = nil
+	if err != nil {
+		if inProgress, _ := conn.ociTransactionInProgress(); inProgress {
+			conn.ociTransRollback()
+		}
+		return err
 	}
 	return nil
 }
 
-// Rollback transaction rollback
-func (tx *Tx) Rollback() error {
-	tx.conn.inTransaction = false
-	if rv := C.OCITransRollback(
-		tx.conn.svc,
-		tx.conn.errHandle,
-		0,
-	); rv != C.OCI_SUCCESS {
-		return tx.conn.getError(rv)
+// Rollback transaction rollback.
+// If the rollback fails with the transaction still in progress, returns driver.ErrBadConn so the connection is not reused.
+func (tx *Tx) Rollback() (err error) {
+	conn := tx.conn
+	if tx.primary != nil {
+		// the read-only transaction ran on the reader session of the primary connection
+		tx.primary.readerTx = false
+	}
+	if conn.hooks != nil && conn.hooks.OnCommit != nil {
+		start := time.Now()
+		defer func() {
+			tx.hookCommit(true, start, err)
+		}()
+	}
+	if conn.badConn {
+		conn.inTransaction = false
+		return driver.ErrBadConn
+	}
+	// the rollback is not limited by the deadline of the transaction context,
+	// because database/sql rolls back the transaction when the context is done
+	conn.setCallTimeout(context.Background())
+	err = conn.ociTransRollback()
+	conn.inTransaction = false
+	conn.savepoints = nil
+	if err != nil {
+		if inProgress, _ := conn.ociTransactionInProgress(); inProgress {
+			conn.logError(tx.ctx, "rollback error", err)
+			return driver.ErrBadConn
+		}
+		return err
 	}
 	return nil
 }
@@ -170,10 +342,24 @@ func (drv *DriverStruct) Open(dsnString string) (driver.Conn, error) {
 		return nil, err
 	}
 
+	return drv.openDSNContext(context.Background(), dsn)
+}
+
+// openDSN opens a new database connection with a parsed DSN
+func (drv *DriverStruct) openDSN(dsn *DSN) (driver.Conn, error) {
+	var err error
+
 	conn := Conn{
 		operationMode: dsn.operationMode,
 		stmtCacheSize: dsn.stmtCacheSize,
-		logger:        drv.Logger,
+
+		callTimeoutDefault: dsn.callTimeout,
+		logger:             drv.Logger,
+		hooks:              drv.Hooks,
+
+		levelLogger:            drv.LevelLogger,
+		slowStatementThreshold: drv.SlowStatementThreshold,
+		logBinds:               drv.LogBindValues,
 	}
 	if conn.logger == nil {
 		conn.logger = log.New(ioutil.Discard, "", 0)
@@ -189,17 +375,23 @@ func (drv *DriverStruct) Open(dsnString string) (driver.Conn, error) {
 		charset = defaultCharset
 	}
 
+	// OCI_EVENTS is needed for Continuous Query Notification subscriptions
+	envMode := C.ub4(C.OCI_THREADED)
+	if dsn.enableEvents {
+		envMode |= C.OCI_EVENTS
+	}
+
 	result = C.OCIEnvNlsCreate(
-		envPP,          // pointer to a handle to the environment
-		C.OCI_THREADED, // environment mode: https://docs.oracle.com/cd/B28359_01/appdev.111/b28395/oci16rel001.htm#LNOCI87683
-		nil,            // Specifies the user-defined context for the memory callback routines.
-		nil,            // Specifies the user-defined memory allocation function. If mode is OCI_THREADED, this memory allocation routine must be thread-safe.
-		nil,            // Specifies the user-defined memory re-allocation function. If the mode is OCI_THREADED, this memory allocation routine must be thread safe.
-		nil,            // Specifies the user-defined memory free function. If mode is OCI_THREADED, this memory free routine must be thread-safe.
-		0,              // Specifies the amount of user memory to be allocated for the duration of the environment.
-		nil,            // Returns a pointer to the user memory of size xtramemsz allocated by the call for the user.
-		charset,        // The client-side character set for the current environment handle. If it is 0, the NLS_LANG setting is used.
-		charset,        // The client-side national character set for the current environment handle. If it is 0, NLS_NCHAR setting is used.
+		envPP,   // pointer to a handle to the environment
+		envMode, // environment mode: https://docs.oracle.com/cd/B28359_01/appdev.111/b28395/oci16rel001.htm#LNOCI87683
+		nil,     // Specifies the user-defined context for the memory callback routines.
+		nil,     // Specifies the user-defined memory allocation function. If mode is OCI_THREADED, this memory allocation routine must be thread-safe.
+		nil,     // Specifies the user-defined memory re-allocation function. If the mode is OCI_THREADED, this memory allocation routine must be thread safe.
+		nil,     // Specifies the user-defined memory free function. If mode is OCI_THREADED, this memory free routine must be thread-safe.
+		0,       // Specifies the amount of user memory to be allocated for the duration of the environment.
+		nil,     // Returns a pointer to the user memory of size xtramemsz allocated by the call for the user.
+		charset, // The client-side character set for the current environment handle. If it is 0, the NLS_LANG setting is used.
+		charset, // The client-side national character set for the current environment handle. If it is 0, NLS_NCHAR setting is used.
 	)
 	if result != C.OCI_SUCCESS {
 		return nil, errors.New("OCIEnvNlsCreate error")
@@ -220,6 +412,7 @@ func (drv *DriverStruct) Open(dsnString string) (driver.Conn, error) {
 					C.OCI_DEFAULT,
 				)
 			}
+			conn.ociEndProxySession()
 			if doneLogon {
 				C.OCILogoff(
 					conn.svc,
@@ -312,6 +505,21 @@ func (drv *DriverStruct) Open(dsnString string) (driver.Conn, error) {
 		}
 		doneServerAttach = true
 
+		if dsn.sendTimeout > 0 {
+			sendTimeout := dsn.sendTimeout
+			err = conn.ociAttrSet(unsafe.Pointer(conn.srv), C.OCI_HTYPE_SERVER, unsafe.Pointer(&sendTimeout), 0, C.OCI_ATTR_SEND_TIMEOUT)
+			if err != nil {
+				return nil, fmt.Errorf("send timeout attribute set error: %v", err)
+			}
+		}
+		if dsn.receiveTimeout > 0 {
+			receiveTimeout := dsn.receiveTimeout
+			err = conn.ociAttrSet(unsafe.Pointer(conn.srv), C.OCI_HTYPE_SERVER, unsafe.Pointer(&receiveTimeout), 0, C.OCI_ATTR_RECEIVE_TIMEOUT)
+			if err != nil {
+				return nil, fmt.Errorf("receive timeout attribute set error: %v", err)
+			}
+		}
+
 		// service handle
 		handle, _, err = conn.ociHandleAlloc(C.OCI_HTYPE_SVCCTX, 0)
 		if err != nil {
@@ -333,7 +541,17 @@ func (drv *DriverStruct) Open(dsnString string) (driver.Conn, error) {
 		conn.usrSession = (*C.OCISession)(*handle)
 
 		credentialType := C.ub4(C.OCI_CRED_EXT)
-		if len(dsn.Username) > 0 {
+		if dsn.targetUser != "" {
+			// the username and password authenticate the proxy user session, then the user session runs as the target user
+			err = conn.ociBeginProxySession(dsn.Username, dsn.Password)
+			if err != nil {
+				return nil, err
+			}
+			credentialType, err = conn.ociSetProxyCredentials(dsn.targetUser)
+			if err != nil {
+				return nil, err
+			}
+		} else if len(dsn.Username) > 0 {
 			// specifies a username to use for authentication
 			err = conn.ociAttrSet(unsafe.Pointer(conn.usrSession), C.OCI_HTYPE_SESSION, unsafe.Pointer(username), C.ub4(len(dsn.Username)), C.OCI_ATTR_USERNAME)
 			if err != nil {
@@ -412,11 +630,23 @@ func (drv *DriverStruct) Open(dsnString string) (driver.Conn, error) {
 		return nil, fmt.Errorf("service context attribute set error: %v", err)
 	}
 
+	// a call_timeout is set now so a client that does not support it fails the connect
+	if dsn.callTimeout > 0 {
+		err = conn.ociSetCallTimeout(dsn.callTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("call timeout attribute set error: %v", err)
+		}
+	}
+
 	conn.transactionMode = dsn.transactionMode
+	conn.commitFlags = dsn.commitFlags
+	conn.disableAutocommit = dsn.disableAutocommit
+	conn.resetPackages = dsn.resetPackages
 	conn.prefetchRows = dsn.prefetchRows
 	conn.prefetchMemory = dsn.prefetchMemory
 	conn.timeLocation = dsn.timeLocation
 	conn.enableQMPlaceholders = dsn.enableQMPlaceholders
+	conn.enableEvents = dsn.enableEvents
 
 	return &conn, nil
 }
@@ -436,15 +666,6 @@ func (result *Result) RowsAffected() (int64, error) {
 	return result.rowsAffected, result.rowsAffectedErr
 }
 
-// converts "?" characters to  :1, :2, ... :n
-func placeholders(sql string) string {
-	n := 0
-	return phre.ReplaceAllStringFunc(sql, func(string) string {
-		n++
-		return ":" + strconv.Itoa(n)
-	})
-}
-
 func timezoneToLocation(hour int64, minute int64) *time.Location {
 	if minute != 0 || hour > 14 || hour < -12 {
 		// create location with FixedZone
@@ -0,0 +1,59 @@
+package oci8
+
+import (
+	"context"
+	"testing"
+)
+
+// TestCommitMode tests transactions and autocommit with the commit modes
+func TestCommitMode(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "COMMIT_MODE_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	modes := []CommitMode{CommitModeDefault, CommitModeNoWait, CommitModeBatch, CommitModeBatchNoWait}
+	for i, mode := range modes {
+		ctx, cancel := context.WithTimeout(WithCommitMode(context.Background(), mode), TestContextTimeout)
+
+		tx, err := TestDB.BeginTx(ctx, nil)
+		if err != nil {
+			cancel()
+			t.Fatal("begin error:", err)
+		}
+		_, err = tx.ExecContext(ctx, "insert into "+tableName+" ( A ) values ( :1 )", int64(i))
+		if err != nil {
+			tx.Rollback()
+			cancel()
+			t.Fatal("insert error:", err)
+		}
+		err = tx.Commit()
+		if err != nil {
+			cancel()
+			t.Fatal("commit error:", err)
+		}
+
+		_, err = TestDB.ExecContext(ctx, "insert into "+tableName+" ( A ) values ( :1 )", int64(i))
+		cancel()
+		if err != nil {
+			t.Fatal("autocommit insert error:", err)
+		}
+	}
+
+	var count int64
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	err = TestDB.QueryRowContext(ctx, "select count(1) from "+tableName).Scan(&count)
+	if err != nil {
+		t.Fatal("select error:", err)
+	}
+	if count != int64(2*len(modes)) {
+		t.Errorf("count - received: %v - expected: %v", count, 2*len(modes))
+	}
+}
@@ -0,0 +1,35 @@
+// +build go1.13
+
+package oci8
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestConnectContext tests that Connector Connect returns when the context is done
+func TestConnectContext(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	t.Parallel()
+
+	connector := NewConnector(TestHostInvalid)
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	conn, err := connector.Connect(ctx)
+	cancel()
+	if conn != nil {
+		conn.Close()
+		t.Fatal("conn is not nil")
+	}
+	if err == nil {
+		t.Fatal("connect error is nil")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("connect elapsed - received: %v - expected less than: %v", elapsed, 5*time.Second)
+	}
+}
@@ -0,0 +1,124 @@
+// +build go1.13
+
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+// TestServerOutput tests getting DBMS_OUTPUT lines
+func TestServerOutput(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	conn, err := TestDB.Conn(ctx)
+	cancel()
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn.Close()
+
+	err = conn.Raw(func(driverConn interface{}) error {
+		return EnableServerOutput(driverConn.(driver.Conn), 0)
+	})
+	if err != nil {
+		t.Fatal("enable server output error:", err)
+	}
+
+	// more lines than serverOutputBatchSize to test multiple GET_LINES calls
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	_, err = conn.ExecContext(ctx, "begin for i in 1..100 loop dbms_output.put_line('line ' || i); end loop; dbms_output.put_line(null); end;")
+	cancel()
+	if err != nil {
+		t.Fatal("exec error:", err)
+	}
+
+	var lines []string
+	err = conn.Raw(func(driverConn interface{}) error {
+		lines, err = ServerOutput(driverConn.(driver.Conn))
+		return err
+	})
+	if err != nil {
+		t.Fatal("server output error:", err)
+	}
+	if len(lines) != 101 {
+		t.Fatalf("len lines - expected: %v - received: %v", 101, len(lines))
+	}
+	if lines[0] != "line 1" || lines[99] != "line 100" || lines[100] != "" {
+		t.Fatalf("lines - received: %v, %v, %v", lines[0], lines[99], lines[100])
+	}
+
+	// buffer should now be empty
+	err = conn.Raw(func(driverConn interface{}) error {
+		lines, err = ServerOutput(driverConn.(driver.Conn))
+		return err
+	})
+	if err != nil {
+		t.Fatal("server output error:", err)
+	}
+	if len(lines) != 0 {
+		t.Fatalf("len lines - expected: %v - received: %v", 0, len(lines))
+	}
+
+	err = conn.Raw(func(driverConn interface{}) error {
+		return DisableServerOutput(driverConn.(driver.Conn))
+	})
+	if err != nil {
+		t.Fatal("disable server output error:", err)
+	}
+}
+
+// TestServerOutputHandler tests DBMS_OUTPUT lines delivered to a handler by the connector
+func TestServerOutputHandler(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	t.Parallel()
+
+	var openString string
+	if len(TestUsername) > 0 {
+		if len(TestPassword) > 0 {
+			openString = TestUsername + "/" + TestPassword + "@"
+		} else {
+			openString = TestUsername + "@"
+		}
+	}
+	openString += TestHostValid
+
+	var received [][]string
+	connector := NewConnector(openString).(*Connector)
+	connector.ServerOutputHandler = func(lines []string) {
+		received = append(received, lines)
+	}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	_, err := db.ExecContext(ctx, "begin dbms_output.put_line('one'); dbms_output.put_line('two'); end;")
+	cancel()
+	if err != nil {
+		t.Fatal("exec error:", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	_, err = db.ExecContext(ctx, "begin null; end;")
+	cancel()
+	if err != nil {
+		t.Fatal("exec error:", err)
+	}
+
+	expected := [][]string{{"one", "two"}}
+	if !reflect.DeepEqual(received, expected) {
+		t.Fatalf("received - expected: %v - received: %v", expected, received)
+	}
+}
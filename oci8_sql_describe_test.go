@@ -0,0 +1,91 @@
+// +build go1.13
+
+package oci8
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+// TestDescribe tests describing a query without executing it
+func TestDescribe(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	conn, err := TestDB.Conn(ctx)
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn.Close()
+
+	var description *Description
+	err = conn.Raw(func(driverConn interface{}) error {
+		description, err = Describe(ctx, driverConn.(driver.Conn),
+			"select cast(:a as varchar2(20)) v, cast(:b as number(10,2)) n, sysdate d, cast(:a as nchar(5)) nc from dual where 1 = :c")
+		return err
+	})
+	if err != nil {
+		t.Fatal("describe error:", err)
+	}
+
+	expectedBindNames := []string{"A", "B", "C"}
+	if !reflect.DeepEqual(description.BindNames, expectedBindNames) {
+		t.Fatalf("bind names - expected: %v - received: %v", expectedBindNames, description.BindNames)
+	}
+
+	if len(description.Columns) != 4 {
+		t.Fatalf("len columns - expected: %v - received: %v", 4, len(description.Columns))
+	}
+
+	var tests = []struct {
+		name             string
+		databaseTypeName string
+		size             int
+		precision        int
+		scale            int
+		charsetForm      int
+	}{
+		{name: "V", databaseTypeName: "VARCHAR2", size: 20, charsetForm: 1},
+		{name: "N", databaseTypeName: "NUMBER", size: 22, precision: 10, scale: 2},
+		{name: "D", databaseTypeName: "DATE", size: 7},
+		{name: "NC", databaseTypeName: "NCHAR", charsetForm: 2},
+	}
+
+	for i, test := range tests {
+		column := description.Columns[i]
+		if column.Name != test.name {
+			t.Errorf("column %v name - expected: %v - received: %v", i, test.name, column.Name)
+		}
+		if column.DatabaseTypeName != test.databaseTypeName {
+			t.Errorf("column %v type - expected: %v - received: %v", i, test.databaseTypeName, column.DatabaseTypeName)
+		}
+		if test.size > 0 && column.Size != test.size {
+			t.Errorf("column %v size - expected: %v - received: %v", i, test.size, column.Size)
+		}
+		if column.Precision != test.precision || column.Scale != test.scale {
+			t.Errorf("column %v precision, scale - expected: %v, %v - received: %v, %v", i, test.precision, test.scale, column.Precision, column.Scale)
+		}
+		if column.CharsetForm != test.charsetForm {
+			t.Errorf("column %v charset form - expected: %v - received: %v", i, test.charsetForm, column.CharsetForm)
+		}
+	}
+
+	// non query statements only have bind names
+	err = conn.Raw(func(driverConn interface{}) error {
+		description, err = Describe(ctx, driverConn.(driver.Conn), "begin null; end;")
+		return err
+	})
+	if err != nil {
+		t.Fatal("describe error:", err)
+	}
+	if len(description.Columns) != 0 || len(description.BindNames) != 0 {
+		t.Fatalf("description - expected empty - received: %+v", description)
+	}
+}
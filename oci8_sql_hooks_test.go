@@ -0,0 +1,103 @@
+// +build go1.13
+
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+// TestHooks tests the hooks are called with the query, statement type, and rows
+func TestHooks(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	var openString string
+	if len(TestUsername) > 0 {
+		if len(TestPassword) > 0 {
+			openString = TestUsername + "/" + TestPassword + "@"
+		} else {
+			openString = TestUsername + "@"
+		}
+	}
+	openString += TestHostValid
+
+	type hookCall struct {
+		name  string
+		event HookEvent
+	}
+	var calls []hookCall
+	record := func(name string) func(ctx context.Context, event HookEvent) {
+		return func(ctx context.Context, event HookEvent) {
+			calls = append(calls, hookCall{name: name, event: event})
+		}
+	}
+
+	connector := NewConnector(openString).(*Connector)
+	connector.Hooks = &Hooks{
+		OnPrepare: record("prepare"),
+		OnExecStart: func(ctx context.Context, event HookEvent) context.Context {
+			record("start")(ctx, event)
+			return ctx
+		},
+		OnExecEnd: record("end"),
+		OnFetch:   record("fetch"),
+		OnCommit:  record("commit"),
+	}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn.Close()
+
+	calls = nil
+	rows, err := conn.QueryContext(ctx, "select :1 from dual union all select :2 from dual", int64(1), int64(2))
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	for rows.Next() {
+	}
+	err = rows.Err()
+	if err != nil {
+		t.Fatal("rows error:", err)
+	}
+	rows.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal("begin error:", err)
+	}
+	err = tx.Rollback()
+	if err != nil {
+		t.Fatal("rollback error:", err)
+	}
+
+	expected := []string{"prepare", "start", "end", "fetch", "commit"}
+	if len(calls) != len(expected) {
+		t.Fatalf("calls - received: %v - expected: %v", calls, expected)
+	}
+	for i := range expected {
+		if calls[i].name != expected[i] {
+			t.Errorf("call %v - received: %v - expected: %v", i, calls[i].name, expected[i])
+		}
+		if calls[i].event.Err != nil {
+			t.Errorf("call %v error: %v", i, calls[i].event.Err)
+		}
+	}
+	if calls[1].event.StatementType != "SELECT" || calls[1].event.NumBinds != 2 {
+		t.Errorf("start - received: %+v - expected: SELECT with 2 binds", calls[1].event)
+	}
+	if calls[3].event.RowsFetched != 2 {
+		t.Errorf("fetch RowsFetched - received: %v - expected: %v", calls[3].event.RowsFetched, 2)
+	}
+	if !calls[4].event.Rollback {
+		t.Error("commit Rollback - received: false - expected: true")
+	}
+}
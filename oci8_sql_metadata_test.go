@@ -0,0 +1,148 @@
+// +build go1.13
+
+package oci8
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+)
+
+// TestDescribeObject tests describing schema objects
+func TestDescribeObject(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	t.Parallel()
+
+	tableName := "DESCRIBE_OBJECT_" + TestTimeString
+	sequenceName := "DESCRIBE_OBJECT_SEQ_" + TestTimeString
+	functionName := "DESCRIBE_OBJECT_FUNC_" + TestTimeString
+
+	queries := []string{
+		"create table " + tableName + " ( A VARCHAR2(20 char) not null, B NUMBER(10,2) default 5, C DATE )",
+		"create sequence " + sequenceName + " minvalue 1 maxvalue 1000 increment by 2 cache 20 order",
+		"create function " + functionName + "(p1 in number, p2 in out varchar2, p3 out date default null) return number as begin return 1; end;",
+	}
+	for _, query := range queries {
+		err := testExec(t, query, nil)
+		if err != nil {
+			t.Fatal("exec error:", err)
+		}
+	}
+	defer func() {
+		for _, query := range []string{"drop table " + tableName, "drop sequence " + sequenceName, "drop function " + functionName} {
+			err := testExec(t, query, nil)
+			if err != nil {
+				t.Error("drop error:", err)
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	conn, err := TestDB.Conn(ctx)
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn.Close()
+
+	describeObject := func(name string) *ObjectDescription {
+		var object *ObjectDescription
+		err = conn.Raw(func(driverConn interface{}) error {
+			object, err = DescribeObject(ctx, driverConn.(driver.Conn), name)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("describe object %v error: %v", name, err)
+		}
+		return object
+	}
+
+	// table
+	object := describeObject(tableName)
+	if object.Type != "TABLE" || object.Name != tableName {
+		t.Fatalf("table - received: %v %v", object.Type, object.Name)
+	}
+	if len(object.Columns) != 3 {
+		t.Fatalf("len columns - expected: %v - received: %v", 3, len(object.Columns))
+	}
+	column := object.Columns[0]
+	if column.Name != "A" || column.DatabaseTypeName != "VARCHAR2" || column.CharLength != 20 || column.Nullable || column.HasDefault {
+		t.Errorf("column A - received: %+v", column)
+	}
+	column = object.Columns[1]
+	if column.Name != "B" || column.DatabaseTypeName != "NUMBER" || column.Precision != 10 || column.Scale != 2 || !column.Nullable || column.Default != "5" {
+		t.Errorf("column B - received: %+v", column)
+	}
+	column = object.Columns[2]
+	if column.Name != "C" || column.DatabaseTypeName != "DATE" || column.HasDefault {
+		t.Errorf("column C - received: %+v", column)
+	}
+
+	// sequence
+	object = describeObject(sequenceName)
+	if object.Type != "SEQUENCE" || object.Sequence == nil {
+		t.Fatalf("sequence - received: %+v", object)
+	}
+	sequence := *object.Sequence
+	if sequence.Min != "1" || sequence.Max != "1000" || sequence.Increment != "2" || sequence.Cache != "20" || !sequence.Order {
+		t.Errorf("sequence - received: %+v", sequence)
+	}
+
+	// function
+	object = describeObject(functionName)
+	if object.Type != "FUNCTION" {
+		t.Fatalf("function - received: %v", object.Type)
+	}
+	if len(object.Arguments) != 4 {
+		t.Fatalf("len arguments - expected: %v - received: %v", 4, len(object.Arguments))
+	}
+	var tests = []struct {
+		name       string
+		position   int
+		direction  string
+		typeName   string
+		hasDefault bool
+	}{
+		{name: "", position: 0, direction: "OUT", typeName: "NUMBER"},
+		{name: "P1", position: 1, direction: "IN", typeName: "NUMBER"},
+		{name: "P2", position: 2, direction: "IN/OUT", typeName: "VARCHAR2"},
+		{name: "P3", position: 3, direction: "OUT", typeName: "DATE", hasDefault: true},
+	}
+	for i, test := range tests {
+		argument := object.Arguments[i]
+		if argument.Name != test.name || argument.Position != test.position || argument.Direction != test.direction ||
+			argument.DatabaseTypeName != test.typeName || argument.HasDefault != test.hasDefault {
+			t.Errorf("argument %v - expected: %+v - received: %+v", i, test, argument)
+		}
+	}
+	// table through a loopback database link, the column defaults are read over the database link
+	if len(TestUsername) > 0 && len(TestPassword) > 0 {
+		linkName := "DESCRIBE_OBJECT_LINK_" + TestTimeString
+		err = testExec(t, "create database link "+linkName+" connect to "+TestUsername+" identified by \""+TestPassword+"\" using '"+TestHostValid+"'", nil)
+		if err != nil {
+			t.Fatal("create database link error:", err)
+		}
+		defer func() {
+			err := testExec(t, "drop database link "+linkName, nil)
+			if err != nil {
+				t.Error("drop database link error:", err)
+			}
+		}()
+
+		object = describeObject(tableName + "@" + linkName)
+		if object.Type != "TABLE" || len(object.Columns) != 3 {
+			t.Fatalf("database link table - received: %+v", object)
+		}
+		column = object.Columns[1]
+		if column.Name != "B" || !column.HasDefault || column.Default != "5" {
+			t.Errorf("database link column B - received: %+v", column)
+		}
+		column = object.Columns[2]
+		if column.Name != "C" || column.HasDefault {
+			t.Errorf("database link column C - received: %+v", column)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// +build go1.13
+
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+// TestTargetUser tests that the session of a proxy_user[target_user] DSN and of a connector TargetUser
+// runs as the target user, authenticated by the proxy user
+func TestTargetUser(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive || len(TestUsername) == 0 || len(TestPassword) == 0 {
+		t.SkipNow()
+	}
+
+	targetUser := "TARGET_" + TestTimeString
+	err := testExec(t, "create user "+targetUser+" identified by \"Target_"+TestTimeString+"\"", nil)
+	if err != nil {
+		t.Fatal("create user error:", err)
+	}
+	defer func() {
+		err := testExec(t, "drop user "+targetUser, nil)
+		if err != nil {
+			t.Error("drop user error:", err)
+		}
+	}()
+	err = testExec(t, "grant create session to "+targetUser, nil)
+	if err != nil {
+		t.Fatal("grant error:", err)
+	}
+	err = testExec(t, "alter user "+targetUser+" grant connect through "+TestUsername, nil)
+	if err != nil {
+		t.Fatal("alter user error:", err)
+	}
+
+	connector := NewConnector(TestUsername + "/" + TestPassword + "@" + TestHostValid).(*Connector)
+	connector.TargetUser = targetUser
+	connectorDB := sql.OpenDB(connector)
+	defer connectorDB.Close()
+
+	dsnDB, err := sql.Open("oci8", TestUsername+"["+targetUser+"]/"+TestPassword+"@"+TestHostValid)
+	if err != nil {
+		t.Fatal("open error:", err)
+	}
+	defer dsnDB.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	for name, db := range map[string]*sql.DB{"connector": connectorDB, "dsn": dsnDB} {
+		var sessionUser, proxyUser string
+		err = db.QueryRowContext(ctx, "select sys_context('USERENV', 'SESSION_USER'), sys_context('USERENV', 'PROXY_USER') from dual").Scan(&sessionUser, &proxyUser)
+		if err != nil {
+			t.Fatal(name, "scan error:", err)
+		}
+		if sessionUser != targetUser {
+			t.Errorf("%v session user - received: %v - expected: %v", name, sessionUser, targetUser)
+		}
+		if proxyUser != strings.ToUpper(TestUsername) {
+			t.Errorf("%v proxy user - received: %v - expected: %v", name, proxyUser, strings.ToUpper(TestUsername))
+		}
+		db.Close()
+	}
+}
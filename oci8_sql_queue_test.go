@@ -0,0 +1,110 @@
+// +build go1.13
+
+package oci8
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"testing"
+)
+
+// TestQueueRaw tests enqueue and dequeue of RAW messages.
+// The user needs execute on DBMS_AQADM and DBMS_AQ.
+func TestQueueRaw(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	queueTable := "QT_" + TestTimeString
+	queueName := "Q_" + TestTimeString
+
+	err := testExec(t, "begin DBMS_AQADM.CREATE_QUEUE_TABLE(queue_table => '"+queueTable+"', queue_payload_type => 'RAW'); end;", nil)
+	if err != nil {
+		t.Fatal("create queue table error:", err)
+	}
+	defer func() {
+		err := testExec(t, "begin DBMS_AQADM.DROP_QUEUE_TABLE(queue_table => '"+queueTable+"', force => TRUE); end;", nil)
+		if err != nil {
+			t.Error("drop queue table error:", err)
+		}
+	}()
+	err = testExec(t, "begin DBMS_AQADM.CREATE_QUEUE(queue_name => '"+queueName+"', queue_table => '"+queueTable+"'); DBMS_AQADM.START_QUEUE(queue_name => '"+queueName+"'); end;", nil)
+	if err != nil {
+		t.Fatal("create queue error:", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	conn, err := TestDB.Conn(ctx)
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn.Close()
+
+	payloads := [][]byte{[]byte("one"), []byte("two"), []byte("three")}
+
+	err = conn.Raw(func(driverConn interface{}) error {
+		queue, err := NewQueue(ctx, driverConn.(driver.Conn), queueName, "")
+		if err != nil {
+			t.Fatal("new queue error:", err)
+		}
+
+		messages := []*Message{{Raw: payloads[0], Correlation: "test"}}
+		err = queue.Enqueue(ctx, messages...)
+		if err != nil {
+			t.Fatal("enqueue error:", err)
+		}
+		if len(messages[0].ID) == 0 {
+			t.Error("message ID is empty")
+		}
+
+		err = queue.Enqueue(ctx, &Message{Raw: payloads[1]}, &Message{Raw: payloads[2]})
+		if err != nil {
+			t.Fatal("enqueue array error:", err)
+		}
+
+		dequeued, err := queue.Dequeue(ctx, 1)
+		if err != nil {
+			t.Fatal("dequeue error:", err)
+		}
+		if len(dequeued) != 1 {
+			t.Fatalf("dequeue - received: %v - expected: %v", len(dequeued), 1)
+		}
+		if !bytes.Equal(dequeued[0].Raw, payloads[0]) {
+			t.Errorf("dequeue - received: %s - expected: %s", dequeued[0].Raw, payloads[0])
+		}
+		if dequeued[0].Correlation != "test" {
+			t.Errorf("correlation - received: %v - expected: %v", dequeued[0].Correlation, "test")
+		}
+		if !bytes.Equal(dequeued[0].ID, messages[0].ID) {
+			t.Errorf("message ID - received: %x - expected: %x", dequeued[0].ID, messages[0].ID)
+		}
+
+		dequeued, err = queue.Dequeue(ctx, 5)
+		if err != nil {
+			t.Fatal("dequeue array error:", err)
+		}
+		if len(dequeued) != 2 {
+			t.Fatalf("dequeue array - received: %v - expected: %v", len(dequeued), 2)
+		}
+		for i, message := range dequeued {
+			if !bytes.Equal(message.Raw, payloads[i+1]) {
+				t.Errorf("dequeue array - received: %s - expected: %s", message.Raw, payloads[i+1])
+			}
+		}
+
+		dequeued, err = queue.Dequeue(ctx, 1)
+		if err != nil {
+			t.Fatal("dequeue empty error:", err)
+		}
+		if len(dequeued) != 0 {
+			t.Errorf("dequeue empty - received: %v - expected: %v", len(dequeued), 0)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal("raw error:", err)
+	}
+}
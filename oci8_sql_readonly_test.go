@@ -0,0 +1,97 @@
+// +build go1.13
+
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+// TestReadOnlyRouting tests read-only transactions and WithReadOnly statements run on the reader session
+func TestReadOnlyRouting(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "READONLY_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	var openString string
+	if len(TestUsername) > 0 {
+		if len(TestPassword) > 0 {
+			openString = TestUsername + "/" + TestPassword + "@"
+		} else {
+			openString = TestUsername + "@"
+		}
+	}
+	openString += TestHostValid
+
+	// the same database is used as the reader, so the reader is a second session
+	connector := NewConnector(openString).(*Connector)
+	connector.ReaderHosts = []string{openString}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn.Close()
+
+	query := "select sys_context('USERENV', 'SID') from dual"
+	var primarySID string
+	err = conn.QueryRowContext(ctx, query).Scan(&primarySID)
+	if err != nil {
+		t.Fatal("primary scan error:", err)
+	}
+
+	var readOnlySID string
+	err = conn.QueryRowContext(WithReadOnly(ctx), query).Scan(&readOnlySID)
+	if err != nil {
+		t.Fatal("read only scan error:", err)
+	}
+	if readOnlySID == primarySID {
+		t.Fatalf("read only SID - received: %v - expected other than: %v", readOnlySID, primarySID)
+	}
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatal("begin tx error:", err)
+	}
+	var txSID string
+	err = tx.QueryRowContext(ctx, query).Scan(&txSID)
+	if err != nil {
+		tx.Rollback()
+		t.Fatal("tx scan error:", err)
+	}
+	if txSID != readOnlySID {
+		tx.Rollback()
+		t.Fatalf("tx SID - received: %v - expected: %v", txSID, readOnlySID)
+	}
+	_, err = tx.ExecContext(ctx, "insert into "+tableName+" ( A ) values ( 1 )")
+	if err == nil || !strings.HasPrefix(err.Error(), "ORA-01456") {
+		tx.Rollback()
+		t.Fatalf("tx exec error - received: %v - expected: ORA-01456", err)
+	}
+	err = tx.Rollback()
+	if err != nil {
+		t.Fatal("rollback error:", err)
+	}
+
+	var afterSID string
+	err = conn.QueryRowContext(ctx, query).Scan(&afterSID)
+	if err != nil {
+		t.Fatal("after scan error:", err)
+	}
+	if afterSID != primarySID {
+		t.Fatalf("after SID - received: %v - expected: %v", afterSID, primarySID)
+	}
+}
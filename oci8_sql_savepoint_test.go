@@ -0,0 +1,99 @@
+// +build go1.13
+
+package oci8
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+)
+
+// TestSavepoint tests savepoints in a transaction
+func TestSavepoint(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "SAVEPOINT_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	conn, err := TestDB.Conn(ctx)
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn.Close()
+
+	err = conn.Raw(func(driverConn interface{}) error {
+		err := Savepoint(ctx, driverConn.(driver.Conn), "a")
+		if err != ErrNotInTransaction {
+			t.Errorf("savepoint outside transaction - received: %v - expected: %v", err, ErrNotInTransaction)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal("raw error:", err)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal("begin error:", err)
+	}
+	defer tx.Rollback()
+
+	insert := func(value int64) {
+		_, err := tx.ExecContext(ctx, "insert into "+tableName+" ( A ) values ( :1 )", value)
+		if err != nil {
+			t.Fatal("insert error:", err)
+		}
+	}
+
+	insert(1)
+	err = conn.Raw(func(driverConn interface{}) error {
+		return Savepoint(ctx, driverConn.(driver.Conn), "first")
+	})
+	if err != nil {
+		t.Fatal("savepoint first error:", err)
+	}
+	insert(2)
+	err = conn.Raw(func(driverConn interface{}) error {
+		return Savepoint(ctx, driverConn.(driver.Conn), "second")
+	})
+	if err != nil {
+		t.Fatal("savepoint second error:", err)
+	}
+	insert(3)
+
+	err = conn.Raw(func(driverConn interface{}) error {
+		err := RollbackToSavepoint(ctx, driverConn.(driver.Conn), "first")
+		if err != nil {
+			return err
+		}
+		err = RollbackToSavepoint(ctx, driverConn.(driver.Conn), "second")
+		if err != ErrSavepointNotFound {
+			t.Errorf("rollback to removed savepoint - received: %v - expected: %v", err, ErrSavepointNotFound)
+		}
+		err = ReleaseSavepoint(driverConn.(driver.Conn), "first")
+		if err != nil {
+			return err
+		}
+		return ReleaseSavepoint(driverConn.(driver.Conn), "first")
+	})
+	if err != ErrSavepointNotFound {
+		t.Fatalf("release - received: %v - expected: %v", err, ErrSavepointNotFound)
+	}
+
+	var count int64
+	err = tx.QueryRowContext(ctx, "select count(1) from "+tableName).Scan(&count)
+	if err != nil {
+		t.Fatal("select error:", err)
+	}
+	if count != 1 {
+		t.Errorf("count - received: %v - expected: %v", count, 1)
+	}
+}
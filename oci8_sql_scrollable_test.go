@@ -0,0 +1,84 @@
+// +build go1.13
+
+package oci8
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"testing"
+)
+
+// TestQueryScrollable tests positioning a scrollable cursor
+func TestQueryScrollable(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	conn, err := TestDB.Conn(ctx)
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn.Close()
+
+	err = conn.Raw(func(driverConn interface{}) error {
+		rows, err := QueryScrollable(ctx, driverConn.(driver.Conn), "select level from dual connect by level <= :1 order by level", 10)
+		if err != nil {
+			t.Fatal("query scrollable error:", err)
+		}
+		defer func() {
+			err := rows.Close()
+			if err != nil {
+				t.Error("rows close error:", err)
+			}
+		}()
+
+		dest := make([]driver.Value, 1)
+
+		var tests = []struct {
+			name     string
+			fetch    func() error
+			expected float64
+		}{
+			{name: "Next", fetch: func() error { return rows.Next(dest) }, expected: 1},
+			{name: "Last", fetch: func() error { return rows.Last(dest) }, expected: 10},
+			{name: "Prior", fetch: func() error { return rows.Prior(dest) }, expected: 9},
+			{name: "Absolute(4)", fetch: func() error { return rows.Absolute(4, dest) }, expected: 4},
+			{name: "Relative(2)", fetch: func() error { return rows.Relative(2, dest) }, expected: 6},
+			{name: "Relative(-3)", fetch: func() error { return rows.Relative(-3, dest) }, expected: 3},
+			{name: "First", fetch: func() error { return rows.First(dest) }, expected: 1},
+		}
+
+		for _, test := range tests {
+			err = test.fetch()
+			if err != nil {
+				t.Fatalf("%v error: %v", test.name, err)
+			}
+			if dest[0] != test.expected {
+				t.Fatalf("%v - expected: %v - received: %v", test.name, test.expected, dest[0])
+			}
+			var position int
+			position, err = rows.Position()
+			if err != nil {
+				t.Fatalf("%v position error: %v", test.name, err)
+			}
+			if position != int(test.expected) {
+				t.Fatalf("%v position - expected: %v - received: %v", test.name, test.expected, position)
+			}
+		}
+
+		err = rows.Absolute(11, dest)
+		if err != io.EOF {
+			t.Fatalf("Absolute(11) - expected: %v - received: %v", io.EOF, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal("raw error:", err)
+	}
+}
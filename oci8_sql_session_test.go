@@ -0,0 +1,144 @@
+// +build go1.13
+
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+// TestResetSession tests ResetSession rolls back and clears the module and IsValid
+func TestResetSession(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "RESET_SESSION_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	conn, err := TestDB.Conn(ctx)
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn.Close()
+
+	err = conn.Raw(func(driverConn interface{}) error {
+		oci8Conn := driverConn.(*Conn)
+		if !oci8Conn.IsValid() {
+			t.Error("IsValid - received: false - expected: true")
+		}
+
+		_, err := oci8Conn.BeginTx(ctx, driver.TxOptions{})
+		if err != nil {
+			return err
+		}
+		_, err = oci8Conn.execSimple(ctx, "insert into "+tableName+" ( A ) values ( 1 )")
+		if err != nil {
+			return err
+		}
+		_, err = oci8Conn.execSimple(ctx, "begin dbms_application_info.set_module('oci8 test', 'reset'); end;")
+		if err != nil {
+			return err
+		}
+
+		err = oci8Conn.ResetSession(ctx)
+		if err != nil {
+			return err
+		}
+		if oci8Conn.InTransaction() {
+			t.Error("InTransaction after ResetSession - received: true - expected: false")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal("raw error:", err)
+	}
+
+	var module sql.NullString
+	err = conn.QueryRowContext(ctx, "select sys_context('USERENV', 'MODULE') from dual").Scan(&module)
+	if err != nil {
+		t.Fatal("select module error:", err)
+	}
+	if module.String == "oci8 test" {
+		t.Errorf("module - received: %v - expected: cleared", module.String)
+	}
+
+	var count int64
+	err = conn.QueryRowContext(ctx, "select count(1) from "+tableName).Scan(&count)
+	if err != nil {
+		t.Fatal("select count error:", err)
+	}
+	if count != 0 {
+		t.Errorf("count - received: %v - expected: %v", count, 0)
+	}
+}
+
+// TestResetSessionCallTimeout tests ResetSession sets the call timeout of the connection again,
+// instead of using the call timeout left by a call with a context deadline
+func TestResetSessionCallTimeout(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	var openString string
+	if len(TestUsername) > 0 {
+		if len(TestPassword) > 0 {
+			openString = TestUsername + "/" + TestPassword + "@"
+		} else {
+			openString = TestUsername + "@"
+		}
+	}
+	openString += TestHostValid + "?call_timeout=30s"
+
+	db, err := sql.Open("oci8", openString)
+	if err != nil {
+		t.Fatal("open error:", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn.Close()
+
+	err = conn.Raw(func(driverConn interface{}) error {
+		oci8Conn := driverConn.(*Conn)
+
+		ctxDeadline, cancelDeadline := context.WithTimeout(ctx, 5*time.Second)
+		defer cancelDeadline()
+		_, err := oci8Conn.execSimple(ctxDeadline, "begin null; end;")
+		if err != nil {
+			return err
+		}
+		if oci8Conn.callTimeoutUnsupported {
+			t.Skip("client does not support OCI_ATTR_CALL_TIMEOUT")
+		}
+		if oci8Conn.callTimeoutCurrent > 5000 {
+			t.Errorf("call timeout with deadline - received: %v - expected: at most %v", oci8Conn.callTimeoutCurrent, 5000)
+		}
+
+		err = oci8Conn.ResetSession(context.Background())
+		if err != nil {
+			return err
+		}
+		if oci8Conn.callTimeoutCurrent != 30000 {
+			t.Errorf("call timeout after ResetSession - received: %v - expected: %v", oci8Conn.callTimeoutCurrent, 30000)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal("raw error:", err)
+	}
+}
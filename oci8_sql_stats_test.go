@@ -0,0 +1,100 @@
+// +build go1.13
+
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+// TestStats tests the connection and connector statistics
+func TestStats(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	var openString string
+	if len(TestUsername) > 0 {
+		if len(TestPassword) > 0 {
+			openString = TestUsername + "/" + TestPassword + "@"
+		} else {
+			openString = TestUsername + "@"
+		}
+	}
+	openString += TestHostValid
+
+	connector := NewConnector(openString).(*Connector)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn.Close()
+
+	var before ConnStats
+	err = conn.Raw(func(driverConn interface{}) error {
+		before = driverConn.(*Conn).Stats()
+		return nil
+	})
+	if err != nil {
+		t.Fatal("raw error:", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, "select to_clob('abc') from dual union all select to_clob('de') from dual")
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	var aString string
+	for rows.Next() {
+		err = rows.Scan(&aString)
+		if err != nil {
+			t.Fatal("scan error:", err)
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		t.Fatal("rows error:", err)
+	}
+	rows.Close()
+
+	var after ConnStats
+	err = conn.Raw(func(driverConn interface{}) error {
+		after = driverConn.(*Conn).Stats()
+		return nil
+	})
+	if err != nil {
+		t.Fatal("raw error:", err)
+	}
+
+	if after.StatementsPrepared-before.StatementsPrepared != 1 {
+		t.Errorf("StatementsPrepared - received: %v - expected: %v", after.StatementsPrepared-before.StatementsPrepared, 1)
+	}
+	if after.StatementsExecuted-before.StatementsExecuted != 1 {
+		t.Errorf("StatementsExecuted - received: %v - expected: %v", after.StatementsExecuted-before.StatementsExecuted, 1)
+	}
+	if after.RowsFetched-before.RowsFetched != 2 {
+		t.Errorf("RowsFetched - received: %v - expected: %v", after.RowsFetched-before.RowsFetched, 2)
+	}
+	if after.LOBBytesRead-before.LOBBytesRead != 5 {
+		t.Errorf("LOBBytesRead - received: %v - expected: %v", after.LOBBytesRead-before.LOBBytesRead, 5)
+	}
+	if after.RoundTrips <= before.RoundTrips {
+		t.Errorf("RoundTrips - received: %v - expected more than: %v", after.RoundTrips, before.RoundTrips)
+	}
+	if after.Memory != 0 {
+		t.Errorf("Memory - received: %v - expected: %v", after.Memory, 0)
+	}
+
+	connectorStats := connector.Stats()
+	if connectorStats.OpenConnections < 1 {
+		t.Errorf("OpenConnections - received: %v - expected at least: %v", connectorStats.OpenConnections, 1)
+	}
+	if connectorStats.RowsFetched < after.RowsFetched {
+		t.Errorf("connector RowsFetched - received: %v - expected at least: %v", connectorStats.RowsFetched, after.RowsFetched)
+	}
+}
@@ -0,0 +1,90 @@
+// +build go1.13
+
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+// TestStatementCache tests statement cache hits and misses with the statement cache options
+func TestStatementCache(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	var openString string
+	if len(TestUsername) > 0 {
+		if len(TestPassword) > 0 {
+			openString = TestUsername + "/" + TestPassword + "@"
+		} else {
+			openString = TestUsername + "@"
+		}
+	}
+	openString += TestHostValid + "?stmt_cache_size=10"
+
+	db, err := sql.Open("oci8", openString)
+	if err != nil {
+		t.Fatal("open error:", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn.Close()
+
+	query := func(ctx context.Context) {
+		var result int64
+		err := conn.QueryRowContext(ctx, "select 1 from dual").Scan(&result)
+		if err != nil {
+			t.Fatal("query error:", err)
+		}
+	}
+	stats := func() ConnStats {
+		var connStats ConnStats
+		err := conn.Raw(func(driverConn interface{}) error {
+			connStats = driverConn.(*Conn).Stats()
+			return nil
+		})
+		if err != nil {
+			t.Fatal("raw error:", err)
+		}
+		return connStats
+	}
+
+	tests := []struct {
+		ctx            context.Context
+		expectedHits   uint64
+		expectedMisses uint64
+	}{
+		{ctx: ctx, expectedHits: 0, expectedMisses: 1},
+		{ctx: ctx, expectedHits: 1, expectedMisses: 1},
+		// bypass of a cached statement is not counted and keeps it cached
+		{ctx: WithStatementCache(ctx, StatementCacheOptions{Bypass: true}), expectedHits: 1, expectedMisses: 1},
+		{ctx: ctx, expectedHits: 2, expectedMisses: 1},
+		// bypass of a statement that is not cached is not counted and does not add it to the cache
+		{ctx: WithStatementCache(ctx, StatementCacheOptions{Key: "bypass", Bypass: true}), expectedHits: 2, expectedMisses: 1},
+		{ctx: WithStatementCache(ctx, StatementCacheOptions{Key: "bypass"}), expectedHits: 2, expectedMisses: 2},
+		{ctx: WithStatementCache(ctx, StatementCacheOptions{Key: "one"}), expectedHits: 2, expectedMisses: 3},
+		{ctx: WithStatementCache(ctx, StatementCacheOptions{Key: "one"}), expectedHits: 3, expectedMisses: 3},
+	}
+
+	for i, tt := range tests {
+		query(tt.ctx)
+		connStats := stats()
+		if connStats.StatementCacheSize != 10 {
+			t.Errorf("%v StatementCacheSize - received: %v - expected: %v", i, connStats.StatementCacheSize, 10)
+		}
+		if connStats.StatementCacheHits != tt.expectedHits {
+			t.Errorf("%v StatementCacheHits - received: %v - expected: %v", i, connStats.StatementCacheHits, tt.expectedHits)
+		}
+		if connStats.StatementCacheMisses != tt.expectedMisses {
+			t.Errorf("%v StatementCacheMisses - received: %v - expected: %v", i, connStats.StatementCacheMisses, tt.expectedMisses)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// +build go1.13
+
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+// TestSubscription tests receiving a change event from a Continuous Query Notification subscription.
+// The user needs the CHANGE NOTIFICATION privilege and the database must be able to connect back to the client.
+func TestSubscription(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "SUBSCRIPTION_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	var openString string
+	if len(TestUsername) > 0 {
+		if len(TestPassword) > 0 {
+			openString = TestUsername + "/" + TestPassword + "@"
+		} else {
+			openString = TestUsername + "@"
+		}
+	}
+	openString += TestHostValid
+
+	connector := NewConnector(openString).(*Connector)
+	connector.Events = true
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn.Close()
+
+	var subscription *Subscription
+	err = conn.Raw(func(driverConn interface{}) error {
+		subscription, err = Subscribe(driverConn.(driver.Conn), SubscriptionOptions{Rowids: true, BufferSize: 10})
+		if err != nil {
+			return err
+		}
+		_, err = subscription.RegisterQuery(ctx, "select A from "+tableName)
+		return err
+	})
+	if err != nil {
+		t.Fatal("subscribe error:", err)
+	}
+
+	_, err = conn.ExecContext(ctx, "insert into "+tableName+" ( A ) values ( 1 )")
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	select {
+	case event := <-subscription.Events():
+		if event.Type != EventObjectChange {
+			t.Fatalf("event type - expected: %v - received: %v", EventObjectChange, event.Type)
+		}
+		if len(event.Tables) != 1 {
+			t.Fatalf("len tables - expected: %v - received: %v", 1, len(event.Tables))
+		}
+		if event.Tables[0].Operation&OperationInsert == 0 {
+			t.Fatalf("table operation - expected insert - received: %v", event.Tables[0].Operation)
+		}
+		if len(event.Tables[0].Rows) != 1 {
+			t.Fatalf("len rows - expected: %v - received: %v", 1, len(event.Tables[0].Rows))
+		}
+	case <-time.After(TestContextTimeout):
+		t.Fatal("timeout waiting for change event")
+	}
+
+	err = conn.Raw(func(driverConn interface{}) error {
+		return subscription.Close()
+	})
+	if err != nil {
+		t.Fatal("subscription close error:", err)
+	}
+
+	_, ok := <-subscription.Events()
+	if ok {
+		t.Fatal("events channel not closed")
+	}
+}
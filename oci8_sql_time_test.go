@@ -792,15 +792,15 @@ func TestDestructiveTimeColumnTypes(t *testing.T) {
 
 	columnNum := 0
 
-	if columnTypes[columnNum].DatabaseTypeName() != "SQLT_TIMESTAMP" {
+	if columnTypes[columnNum].DatabaseTypeName() != "TIMESTAMP" {
 		t.Error("DatabaseTypeName does not match -", columnTypes[columnNum].DatabaseTypeName())
 	}
 
 	length, ok := columnTypes[columnNum].Length()
-	if length != 8 {
+	if length != 0 {
 		t.Error("Length does not match -", length)
 	}
-	if ok != true {
+	if ok != false {
 		t.Error("Length ok does not match -", ok)
 	}
 
@@ -816,15 +816,15 @@ func TestDestructiveTimeColumnTypes(t *testing.T) {
 
 	columnNum = 1
 
-	if columnTypes[columnNum].DatabaseTypeName() != "SQLT_TIMESTAMP_TZ" {
+	if columnTypes[columnNum].DatabaseTypeName() != "TIMESTAMP WITH TIME ZONE" {
 		t.Error("DatabaseTypeName does not match -", columnTypes[columnNum].DatabaseTypeName())
 	}
 
 	length, ok = columnTypes[columnNum].Length()
-	if length != 8 {
+	if length != 0 {
 		t.Error("Length does not match -", length)
 	}
-	if ok != true {
+	if ok != false {
 		t.Error("Length ok does not match -", ok)
 	}
 
@@ -840,15 +840,15 @@ func TestDestructiveTimeColumnTypes(t *testing.T) {
 
 	columnNum = 2
 
-	if columnTypes[columnNum].DatabaseTypeName() != "SQLT_TIMESTAMP_TZ" {
+	if columnTypes[columnNum].DatabaseTypeName() != "TIMESTAMP WITH LOCAL TIME ZONE" {
 		t.Error("DatabaseTypeName does not match -", columnTypes[columnNum].DatabaseTypeName())
 	}
 
 	length, ok = columnTypes[columnNum].Length()
-	if length != 8 {
+	if length != 0 {
 		t.Error("Length does not match -", length)
 	}
-	if ok != true {
+	if ok != false {
 		t.Error("Length ok does not match -", ok)
 	}
 
@@ -864,15 +864,15 @@ func TestDestructiveTimeColumnTypes(t *testing.T) {
 
 	columnNum = 3
 
-	if columnTypes[columnNum].DatabaseTypeName() != "SQLT_INTERVAL_YM" {
+	if columnTypes[columnNum].DatabaseTypeName() != "INTERVAL YEAR TO MONTH" {
 		t.Error("DatabaseTypeName does not match -", columnTypes[columnNum].DatabaseTypeName())
 	}
 
 	length, ok = columnTypes[columnNum].Length()
-	if length != 8 {
+	if length != 0 {
 		t.Error("Length does not match -", length)
 	}
-	if ok != true {
+	if ok != false {
 		t.Error("Length ok does not match -", ok)
 	}
 
@@ -888,15 +888,15 @@ func TestDestructiveTimeColumnTypes(t *testing.T) {
 
 	columnNum = 4
 
-	if columnTypes[columnNum].DatabaseTypeName() != "SQLT_INTERVAL_DS" {
+	if columnTypes[columnNum].DatabaseTypeName() != "INTERVAL DAY TO SECOND" {
 		t.Error("DatabaseTypeName does not match -", columnTypes[columnNum].DatabaseTypeName())
 	}
 
 	length, ok = columnTypes[columnNum].Length()
-	if length != 8 {
+	if length != 0 {
 		t.Error("Length does not match -", length)
 	}
-	if ok != true {
+	if ok != false {
 		t.Error("Length ok does not match -", ok)
 	}
 
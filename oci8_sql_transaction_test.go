@@ -0,0 +1,88 @@
+// +build go1.13
+
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+// TestAutocommitFalse tests autocommit=false and InTransaction
+func TestAutocommitFalse(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "AUTOCOMMIT_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	var openString string
+	if len(TestUsername) > 0 {
+		if len(TestPassword) > 0 {
+			openString = TestUsername + "/" + TestPassword + "@"
+		} else {
+			openString = TestUsername + "@"
+		}
+	}
+	openString += TestHostValid + "?autocommit=false"
+
+	db, err := sql.Open("oci8", openString)
+	if err != nil {
+		t.Fatal("open error:", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn.Close()
+
+	inTransaction := func() bool {
+		var inProgress bool
+		err := conn.Raw(func(driverConn interface{}) error {
+			inProgress = driverConn.(*Conn).InTransaction()
+			return nil
+		})
+		if err != nil {
+			t.Fatal("raw error:", err)
+		}
+		return inProgress
+	}
+
+	if inTransaction() {
+		t.Error("in transaction before insert")
+	}
+
+	_, err = conn.ExecContext(ctx, "insert into "+tableName+" ( A ) values ( 1 )")
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+	if !inTransaction() {
+		t.Error("not in transaction after insert")
+	}
+
+	_, err = conn.ExecContext(ctx, "rollback")
+	if err != nil {
+		t.Fatal("rollback error:", err)
+	}
+	if inTransaction() {
+		t.Error("in transaction after rollback")
+	}
+
+	var count int64
+	err = conn.QueryRowContext(ctx, "select count(1) from "+tableName).Scan(&count)
+	if err != nil {
+		t.Fatal("select error:", err)
+	}
+	if count != 0 {
+		t.Errorf("count - received: %v - expected: %v", count, 0)
+	}
+}
@@ -0,0 +1,91 @@
+// +build go1.13
+
+package oci8
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+// TestXA tests a distributed transaction branch that is detached, resumed on another connection, then committed with two-phase commit.
+// The user needs the FORCE ANY TRANSACTION privilege.
+func TestXA(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "XA_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	conn1, err := TestDB.Conn(ctx)
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn1.Close()
+	conn2, err := TestDB.Conn(ctx)
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn2.Close()
+
+	xid := XID{FormatID: 1, GlobalTransactionID: []byte("gtrid_" + TestTimeString), BranchQualifier: []byte("bqual")}
+
+	err = conn1.Raw(func(driverConn interface{}) error {
+		err := XAStart(ctx, driverConn.(driver.Conn), xid, XAStartNew, time.Minute)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal("start error:", err)
+	}
+
+	_, err = conn1.ExecContext(ctx, "insert into "+tableName+" ( A ) values ( 1 )")
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	err = conn1.Raw(func(driverConn interface{}) error {
+		return XADetach(ctx, driverConn.(driver.Conn))
+	})
+	if err != nil {
+		t.Fatal("detach error:", err)
+	}
+
+	err = conn2.Raw(func(driverConn interface{}) error {
+		err := XAStart(ctx, driverConn.(driver.Conn), xid, XAStartResume, 0)
+		if err != nil {
+			return err
+		}
+		readOnly, err := XAPrepare(ctx, driverConn.(driver.Conn), nil)
+		if err != nil {
+			return err
+		}
+		if readOnly {
+			t.Error("prepare - received: read-only - expected: prepared")
+		}
+		return XACommit(ctx, driverConn.(driver.Conn), &xid, false)
+	})
+	if err != nil {
+		t.Fatal("commit error:", err)
+	}
+
+	var count int64
+	err = conn1.QueryRowContext(ctx, "select count(1) from "+tableName).Scan(&count)
+	if err != nil {
+		t.Fatal("select error:", err)
+	}
+	if count != 1 {
+		t.Errorf("count - received: %v - expected: %v", count, 1)
+	}
+}
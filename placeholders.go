@@ -0,0 +1,195 @@
+package oci8
+
+import (
+	"strconv"
+	"strings"
+)
+
+// parsePlaceholders scans an Oracle SQL or PL/SQL statement for bind placeholders.
+// String literals, q-quoted literals, quoted identifiers, and comments are skipped.
+// When rewriteQM is true, each ? placeholder is replaced with :1, :2, ... :n.
+// Returns the query and the number of inputs the statement needs,
+// or -1 if it can not be known before the arguments are bound.
+func parsePlaceholders(query string, rewriteQM bool) (string, int) {
+	var builder strings.Builder
+	var names []string
+	numQM := 0
+	last := 0
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			// single line comment
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				i = len(query)
+			} else {
+				i += end + 1
+			}
+
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			// multi line comment
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				i = len(query)
+			} else {
+				i += end + 4
+			}
+
+		case c == '\'' || c == '"':
+			// string literal or quoted identifier, a quote is escaped by doubling it
+			i++
+			for i < len(query) {
+				if query[i] == c {
+					if i+1 < len(query) && query[i+1] == c {
+						i += 2
+						continue
+					}
+					break
+				}
+				i++
+			}
+			i++
+
+		case c == '?':
+			numQM++
+			names = append(names, strconv.Itoa(numQM))
+			if rewriteQM {
+				builder.WriteString(query[last:i])
+				builder.WriteString(":" + strconv.Itoa(numQM))
+				last = i + 1
+			}
+			i++
+
+		case c == ':' && i+1 < len(query) && (isIdentifierByte(query[i+1]) || query[i+1] == '"'):
+			// bind name, like :1, :name, or :"Name"
+			start := i + 1
+			i = start
+			if query[i] == '"' {
+				end := strings.IndexByte(query[i+1:], '"')
+				if end < 0 {
+					i = len(query)
+				} else {
+					i += end + 2
+				}
+				names = append(names, strings.Trim(query[start:i], `"`))
+			} else {
+				for i < len(query) && isIdentifierByte(query[i]) {
+					i++
+				}
+				names = append(names, strings.ToUpper(query[start:i]))
+			}
+
+		case isIdentifierByte(c):
+			start := i
+			for i < len(query) && isIdentifierByte(query[i]) {
+				i++
+			}
+			// q-quoted literal, like q'[it's]' or nq'[it's]'
+			word := strings.ToUpper(query[start:i])
+			if (word == "Q" || word == "NQ") && i+1 < len(query) && query[i] == '\'' {
+				i = skipQQuote(query, i+1)
+			}
+
+		default:
+			i++
+		}
+	}
+
+	if rewriteQM && numQM > 0 {
+		builder.WriteString(query[last:])
+		query = builder.String()
+	}
+
+	return query, numInput(query, names)
+}
+
+// numInput returns the number of inputs for the bind names of a statement, or -1 if unknown
+func numInput(query string, names []string) int {
+	switch firstKeyword(query) {
+	case "CREATE", "ALTER":
+		// DDL does not take binds, but a trigger body can reference :new and :old
+		return -1
+	case "BEGIN", "DECLARE":
+		// in PL/SQL a repeated bind name is one input
+		return len(uniqueNames(names))
+	}
+
+	// in SQL a repeated bind name is one input when bound by name but one input per occurrence when bound by position
+	unique := uniqueNames(names)
+	if len(unique) != len(names) {
+		return -1
+	}
+	return len(names)
+}
+
+// uniqueNames returns names without duplicates
+func uniqueNames(names []string) []string {
+	seen := make(map[string]struct{}, len(names))
+	unique := make([]string, 0, len(names))
+	for _, name := range names {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		unique = append(unique, name)
+	}
+	return unique
+}
+
+// firstKeyword returns the first word of the query in upper case, skipping white space, comments, and parentheses
+func firstKeyword(query string) string {
+	for {
+		query = strings.TrimLeft(query, " \t\r\n(")
+		switch {
+		case strings.HasPrefix(query, "--"):
+			end := strings.IndexByte(query, '\n')
+			if end < 0 {
+				return ""
+			}
+			query = query[end+1:]
+		case strings.HasPrefix(query, "/*"):
+			end := strings.Index(query, "*/")
+			if end < 0 {
+				return ""
+			}
+			query = query[end+2:]
+		default:
+			end := 0
+			for end < len(query) && isIdentifierByte(query[end]) {
+				end++
+			}
+			return strings.ToUpper(query[:end])
+		}
+	}
+}
+
+// skipQQuote returns the index after the end of a q-quoted literal, start is the index of the opening delimiter
+func skipQQuote(query string, start int) int {
+	closing := query[start]
+	switch closing {
+	case '[':
+		closing = ']'
+	case '{':
+		closing = '}'
+	case '<':
+		closing = '>'
+	case '(':
+		closing = ')'
+	}
+
+	for i := start + 1; i+1 < len(query); i++ {
+		if query[i] == closing && query[i+1] == '\'' {
+			return i + 2
+		}
+	}
+	return len(query)
+}
+
+// isIdentifierByte returns true if c can be part of an unquoted identifier or bind name.
+// Bytes of multi-byte UTF-8 characters are treated as part of an identifier.
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c == '$' || c == '#' || c >= 0x80 ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,113 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// splitTargetUser splits a proxy_user[target_user] username into the proxy user, which authenticates,
+// and the target user, which the session runs as.
+// Returns the username and an empty target user if the username has no brackets.
+func splitTargetUser(username string) (string, string, error) {
+	start := strings.IndexByte(username, '[')
+	if start < 0 {
+		if strings.IndexByte(username, ']') > -1 {
+			return "", "", fmt.Errorf("invalid target user: %v", username)
+		}
+		return username, "", nil
+	}
+	if start == 0 || !strings.HasSuffix(username, "]") || strings.Count(username, "[") > 1 || strings.Count(username, "]") > 1 {
+		return "", "", fmt.Errorf("invalid target user: %v", username)
+	}
+	targetUser := username[start+1 : len(username)-1]
+	if targetUser == "" {
+		return "", "", fmt.Errorf("invalid target user: %v", username)
+	}
+	return username[:start], targetUser, nil
+}
+
+// ociBeginProxySession begins the session of the proxy user, which authenticates with its username and password,
+// then is passed to the target user session with OCI_ATTR_PROXY_CREDENTIALS
+func (conn *Conn) ociBeginProxySession(username string, password string) error {
+	handle, _, err := conn.ociHandleAlloc(C.OCI_HTYPE_SESSION, 0)
+	if err != nil {
+		return fmt.Errorf("allocate proxy session handle error: %v", err)
+	}
+	proxySession := (*C.OCISession)(*handle)
+
+	usernameP := cString(username)
+	defer C.free(unsafe.Pointer(usernameP))
+	passwordP := cString(password)
+	defer C.free(unsafe.Pointer(passwordP))
+
+	err = conn.ociAttrSet(unsafe.Pointer(proxySession), C.OCI_HTYPE_SESSION, unsafe.Pointer(usernameP), C.ub4(len(username)), C.OCI_ATTR_USERNAME)
+	if err != nil {
+		C.OCIHandleFree(unsafe.Pointer(proxySession), C.OCI_HTYPE_SESSION)
+		return fmt.Errorf("proxy username attribute set error: %v", err)
+	}
+	err = conn.ociAttrSet(unsafe.Pointer(proxySession), C.OCI_HTYPE_SESSION, unsafe.Pointer(passwordP), C.ub4(len(password)), C.OCI_ATTR_PASSWORD)
+	if err != nil {
+		C.OCIHandleFree(unsafe.Pointer(proxySession), C.OCI_HTYPE_SESSION)
+		return fmt.Errorf("proxy password attribute set error: %v", err)
+	}
+
+	result := C.OCISessionBegin(
+		conn.svc,             // service context
+		conn.errHandle,       // error handle
+		proxySession,         // user session context
+		C.OCI_CRED_RDBMS,     // type of credentials to use for establishing the user session
+		C.ub4(C.OCI_DEFAULT), // mode of operation
+	)
+	if result != C.OCI_SUCCESS && result != C.OCI_SUCCESS_WITH_INFO {
+		err = conn.getError(result)
+		C.OCIHandleFree(unsafe.Pointer(proxySession), C.OCI_HTYPE_SESSION)
+		return err
+	}
+	conn.proxySession = proxySession
+
+	// the proxy session is the session of the service context until the target user session begins
+	err = conn.ociAttrSet(unsafe.Pointer(conn.svc), C.OCI_HTYPE_SVCCTX, unsafe.Pointer(proxySession), 0, C.OCI_ATTR_SESSION)
+	if err != nil {
+		conn.ociEndProxySession()
+		return fmt.Errorf("proxy authentication context attribute set error: %v", err)
+	}
+
+	return nil
+}
+
+// ociSetProxyCredentials sets the target user as the username of the user session
+// and the proxy session as its credentials, then returns the credential type for OCISessionBegin
+func (conn *Conn) ociSetProxyCredentials(targetUser string) (C.ub4, error) {
+	targetUserP := cString(targetUser)
+	defer C.free(unsafe.Pointer(targetUserP))
+
+	err := conn.ociAttrSet(unsafe.Pointer(conn.usrSession), C.OCI_HTYPE_SESSION, unsafe.Pointer(targetUserP), C.ub4(len(targetUser)), C.OCI_ATTR_USERNAME)
+	if err != nil {
+		return 0, fmt.Errorf("target user attribute set error: %v", err)
+	}
+	err = conn.ociAttrSet(unsafe.Pointer(conn.usrSession), C.OCI_HTYPE_SESSION, unsafe.Pointer(conn.proxySession), 0, C.OCI_ATTR_PROXY_CREDENTIALS)
+	if err != nil {
+		return 0, fmt.Errorf("proxy credentials attribute set error: %v", err)
+	}
+	return C.OCI_CRED_PROXY, nil
+}
+
+// ociEndProxySession ends then frees the proxy session
+func (conn *Conn) ociEndProxySession() error {
+	if conn.proxySession == nil {
+		return nil
+	}
+	result := C.OCISessionEnd(
+		conn.svc,          // service context
+		conn.errHandle,    // error handle
+		conn.proxySession, // user session context
+		C.OCI_DEFAULT,     // mode
+	)
+	C.OCIHandleFree(unsafe.Pointer(conn.proxySession), C.OCI_HTYPE_SESSION)
+	conn.proxySession = nil
+	return conn.getError(result)
+}
@@ -0,0 +1,957 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// Visibility of an enqueue or dequeue
+const (
+	// VisibilityOnCommit makes the enqueue or dequeue part of the current transaction.
+	// When the connection is not in a transaction, it is committed after the enqueue or dequeue.
+	VisibilityOnCommit Visibility = iota
+	// VisibilityImmediate commits the enqueue or dequeue in its own transaction
+	VisibilityImmediate
+)
+
+// Dequeue modes
+const (
+	// DequeueRemove reads and removes the message
+	DequeueRemove DequeueMode = iota
+	// DequeueBrowse reads the message without locking it
+	DequeueBrowse
+	// DequeueLocked reads and locks the message
+	DequeueLocked
+	// DequeueRemoveNoData removes the message without returning the payload
+	DequeueRemoveNoData
+)
+
+// Dequeue navigations
+const (
+	// NavigationNextMessage dequeues the next available message
+	NavigationNextMessage Navigation = iota
+	// NavigationFirstMessage dequeues the first available message, resetting the position to the beginning of the queue
+	NavigationFirstMessage
+	// NavigationNextTransaction skips the rest of the current transaction group and dequeues the first message of the next one
+	NavigationNextTransaction
+)
+
+// Message states
+const (
+	MessageReady     MessageState = C.OCI_MSG_READY
+	MessageWaiting   MessageState = C.OCI_MSG_WAITING
+	MessageProcessed MessageState = C.OCI_MSG_PROCESSED
+	MessageExpired   MessageState = C.OCI_MSG_EXPIRED
+)
+
+// WaitForever is the DequeueOptions Wait to wait forever for a message
+const WaitForever time.Duration = -1
+
+// NewQueue returns the Advanced Queuing queue name on conn.
+// The payloadTypeName is the object type of the queue payload, like SCOTT.MESSAGE_TYPE, or empty or RAW for a RAW queue.
+// Enqueue and dequeue use the current transaction of conn when the visibility is VisibilityOnCommit,
+// so they commit atomically with other statements of the transaction.
+// The connection must stay open while the queue is used.
+func NewQueue(ctx context.Context, conn driver.Conn, name string, payloadTypeName string) (*Queue, error) {
+	oci8Conn, err := toConn(conn)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	queue := &Queue{
+		conn: oci8Conn,
+		name: name,
+	}
+
+	if payloadTypeName == "" || strings.EqualFold(payloadTypeName, "RAW") {
+		queue.payloadType, err = oci8Conn.ociTypeByName(ctx, "SYS", "RAW")
+		if err != nil {
+			return nil, err
+		}
+		return queue, nil
+	}
+
+	schema, typeName := "", payloadTypeName
+	if i := strings.IndexByte(payloadTypeName, '.'); i > -1 {
+		schema, typeName = payloadTypeName[:i], payloadTypeName[i+1:]
+	}
+	queue.payloadType, err = oci8Conn.ociTypeByName(ctx, schema, typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	queue.attributes, err = oci8Conn.describeTypeAttributes(ctx, payloadTypeName)
+	if err != nil {
+		return nil, err
+	}
+
+	return queue, nil
+}
+
+// Enqueue enqueues the messages, more than one message is enqueued with an array enqueue.
+// The message IDs are set on the messages.
+func (queue *Queue) Enqueue(ctx context.Context, messages ...*Message) error {
+	if len(messages) < 1 {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	conn := queue.conn
+
+	optionsP, _, err := conn.ociDescriptorAlloc(C.OCI_DTYPE_AQENQ_OPTIONS, 0)
+	if err != nil {
+		return err
+	}
+	options := *optionsP
+	defer C.OCIDescriptorFree(options, C.OCI_DTYPE_AQENQ_OPTIONS)
+
+	visibility := C.ub4(C.OCI_ENQ_ON_COMMIT)
+	if queue.EnqueueOptions.Visibility == VisibilityImmediate {
+		visibility = C.OCI_ENQ_IMMEDIATE
+	}
+	err = conn.ociAttrSet(options, C.OCI_DTYPE_AQENQ_OPTIONS, unsafe.Pointer(&visibility), 0, C.OCI_ATTR_VISIBILITY)
+	if err != nil {
+		return err
+	}
+
+	payloads := newQueuePayloads(len(messages))
+	defer queue.freePayloads(payloads)
+
+	for i, message := range messages {
+		payloads.properties[i], err = queue.newMessageProperties(message)
+		if err != nil {
+			return err
+		}
+		err = queue.setPayload(payloads, i, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	name := cString(queue.name)
+	defer C.free(unsafe.Pointer(name))
+
+	var result C.sword
+	done := conn.ociBreakOnDone(ctx)
+	if len(messages) == 1 {
+		result = C.OCIAQEnq(
+			conn.svc,                      // service context handle
+			conn.errHandle,                // error handle
+			name,                          // the target queue for the enqueue operation
+			(*C.OCIAQEnqOptions)(options), // the options for the enqueue operation
+			payloads.properties[0],        // the message properties
+			queue.payloadType,             // the type descriptor object of the payload
+			&payloads.payloads[0],         // pointer to the payload
+			&payloads.indicators[0],       // pointer to the payload null indicator
+			&payloads.ids[0],              // returns the message id
+			C.OCI_DEFAULT,                 // flags, not used
+		)
+	} else {
+		iters := C.ub4(len(messages))
+		result = C.OCIAQEnqArray(
+			conn.svc,                      // service context handle
+			conn.errHandle,                // error handle
+			name,                          // the target queue for the enqueue operation
+			(*C.OCIAQEnqOptions)(options), // the options for the enqueue operation
+			&iters,                        // the number of messages to enqueue, returns the number enqueued
+			&payloads.properties[0],       // array of message properties
+			queue.payloadType,             // the type descriptor object of the payload
+			&payloads.payloads[0],         // array of pointers to the payloads
+			&payloads.indicators[0],       // array of pointers to the payload null indicators
+			&payloads.ids[0],              // returns the message ids
+			nil,                           // context of the callback, no callback is used
+			nil,                           // callback for each message, no callback is used
+			C.OCI_DEFAULT,                 // flags, not used
+		)
+	}
+	closeDone(done)
+	if result != C.OCI_SUCCESS {
+		return conn.getError(result)
+	}
+
+	for i, message := range messages {
+		message.ID = queue.rawBytes(payloads.ids[i])
+	}
+
+	if queue.EnqueueOptions.Visibility == VisibilityOnCommit {
+		return conn.commitOutsideTransaction()
+	}
+
+	return nil
+}
+
+// Dequeue dequeues up to count messages, more than one message is dequeued with an array dequeue.
+// Waits for a message as set by DequeueOptions Wait. If no message is available, returns no messages and no error.
+func (queue *Queue) Dequeue(ctx context.Context, count int) ([]*Message, error) {
+	if count < 1 {
+		count = 1
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	conn := queue.conn
+
+	options, err := queue.newDequeueOptions()
+	if err != nil {
+		return nil, err
+	}
+	defer C.OCIDescriptorFree(options, C.OCI_DTYPE_AQDEQ_OPTIONS)
+
+	payloads := newQueuePayloads(count)
+	defer queue.freePayloads(payloads)
+
+	for i := 0; i < count; i++ {
+		var properties *unsafe.Pointer
+		properties, _, err = conn.ociDescriptorAlloc(C.OCI_DTYPE_AQMSG_PROPERTIES, 0)
+		if err != nil {
+			return nil, err
+		}
+		payloads.properties[i] = (*C.OCIAQMsgProperties)(*properties)
+		if queue.attributes == nil {
+			payloads.indicators[i] = unsafe.Pointer(&payloads.rawIndicators[i])
+		}
+		// a nil payload is allocated by OCI in the object cache
+	}
+
+	name := cString(queue.name)
+	defer C.free(unsafe.Pointer(name))
+
+	var result C.sword
+	iters := C.ub4(count)
+	done := conn.ociBreakOnDone(ctx)
+	if count == 1 {
+		result = C.OCIAQDeq(
+			conn.svc,                      // service context handle
+			conn.errHandle,                // error handle
+			name,                          // the target queue for the dequeue operation
+			(*C.OCIAQDeqOptions)(options), // the options for the dequeue operation
+			payloads.properties[0],        // returns the message properties
+			queue.payloadType,             // the type descriptor object of the payload
+			&payloads.payloads[0],         // pointer to the payload
+			&payloads.indicators[0],       // pointer to the payload null indicator
+			&payloads.ids[0],              // returns the message id
+			C.OCI_DEFAULT,                 // flags, not used
+		)
+	} else {
+		result = C.OCIAQDeqArray(
+			conn.svc,                      // service context handle
+			conn.errHandle,                // error handle
+			name,                          // the target queue for the dequeue operation
+			(*C.OCIAQDeqOptions)(options), // the options for the dequeue operation
+			&iters,                        // the max number of messages to dequeue, returns the number dequeued
+			&payloads.properties[0],       // array of message properties
+			queue.payloadType,             // the type descriptor object of the payload
+			&payloads.payloads[0],         // array of pointers to the payloads
+			&payloads.indicators[0],       // array of pointers to the payload null indicators
+			&payloads.ids[0],              // returns the message ids
+			nil,                           // context of the callback, no callback is used
+			nil,                           // callback for each message, no callback is used
+			C.OCI_DEFAULT,                 // flags, not used
+		)
+	}
+	closeDone(done)
+	if result == C.OCI_ERROR {
+		errorCode, _ := conn.ociGetError()
+		if errorCode == 25228 {
+			// ORA-25228: timeout or end-of-fetch during message dequeue
+			return nil, nil
+		}
+	}
+	if result != C.OCI_SUCCESS {
+		return nil, conn.getError(result)
+	}
+
+	messages := make([]*Message, int(iters))
+	for i := range messages {
+		messages[i], err = queue.message(payloads, i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if queue.DequeueOptions.Visibility == VisibilityOnCommit {
+		err = conn.commitOutsideTransaction()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return messages, nil
+}
+
+// newDequeueOptions returns a dequeue options descriptor set from DequeueOptions
+func (queue *Queue) newDequeueOptions() (unsafe.Pointer, error) {
+	conn := queue.conn
+	dequeueOptions := queue.DequeueOptions
+
+	optionsP, _, err := conn.ociDescriptorAlloc(C.OCI_DTYPE_AQDEQ_OPTIONS, 0)
+	if err != nil {
+		return nil, err
+	}
+	options := *optionsP
+
+	setUb4 := func(value C.ub4, attributeType C.ub4) {
+		if err == nil {
+			err = conn.ociAttrSet(options, C.OCI_DTYPE_AQDEQ_OPTIONS, unsafe.Pointer(&value), 0, attributeType)
+		}
+	}
+	setText := func(value string, attributeType C.ub4) {
+		if err == nil && value != "" {
+			text := cString(value)
+			err = conn.ociAttrSet(options, C.OCI_DTYPE_AQDEQ_OPTIONS, unsafe.Pointer(text), C.ub4(len(value)), attributeType)
+			C.free(unsafe.Pointer(text))
+		}
+	}
+
+	switch dequeueOptions.Mode {
+	case DequeueBrowse:
+		setUb4(C.OCI_DEQ_BROWSE, C.OCI_ATTR_DEQ_MODE)
+	case DequeueLocked:
+		setUb4(C.OCI_DEQ_LOCKED, C.OCI_ATTR_DEQ_MODE)
+	case DequeueRemoveNoData:
+		setUb4(C.OCI_DEQ_REMOVE_NODATA, C.OCI_ATTR_DEQ_MODE)
+	default:
+		setUb4(C.OCI_DEQ_REMOVE, C.OCI_ATTR_DEQ_MODE)
+	}
+
+	switch dequeueOptions.Navigation {
+	case NavigationFirstMessage:
+		setUb4(C.OCI_DEQ_FIRST_MSG, C.OCI_ATTR_NAVIGATION)
+	case NavigationNextTransaction:
+		setUb4(C.OCI_DEQ_NEXT_TRANSACTION, C.OCI_ATTR_NAVIGATION)
+	default:
+		setUb4(C.OCI_DEQ_NEXT_MSG, C.OCI_ATTR_NAVIGATION)
+	}
+
+	if dequeueOptions.Visibility == VisibilityImmediate {
+		setUb4(C.OCI_DEQ_IMMEDIATE, C.OCI_ATTR_VISIBILITY)
+	} else {
+		setUb4(C.OCI_DEQ_ON_COMMIT, C.OCI_ATTR_VISIBILITY)
+	}
+
+	// OCI_DEQ_WAIT_FOREVER is -1, which is the max ub4
+	wait := C.sb4(dequeueWaitSeconds(dequeueOptions.Wait))
+	if dequeueOptions.Wait < 0 {
+		wait = C.OCI_DEQ_WAIT_FOREVER
+	}
+	setUb4(C.ub4(wait), C.OCI_ATTR_WAIT)
+
+	setText(dequeueOptions.Consumer, C.OCI_ATTR_CONSUMER_NAME)
+	setText(dequeueOptions.Correlation, C.OCI_ATTR_CORRELATION)
+	setText(dequeueOptions.Condition, C.OCI_ATTR_DEQCOND)
+
+	if err == nil && len(dequeueOptions.MessageID) > 0 {
+		var raw *C.OCIRaw
+		raw, err = queue.newRaw(dequeueOptions.MessageID)
+		if err == nil {
+			err = conn.ociAttrSet(options, C.OCI_DTYPE_AQDEQ_OPTIONS, unsafe.Pointer(raw), 0, C.OCI_ATTR_DEQ_MSGID)
+			C.OCIRawResize(conn.env, conn.errHandle, 0, &raw)
+		}
+	}
+
+	if err != nil {
+		C.OCIDescriptorFree(options, C.OCI_DTYPE_AQDEQ_OPTIONS)
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// newQueuePayloads returns C allocated payload arrays of size count
+func newQueuePayloads(count int) *queuePayloads {
+	size := C.size_t(count)
+	return &queuePayloads{
+		count:         count,
+		properties:    (*[1 << 26]*C.OCIAQMsgProperties)(C.calloc(size, C.size_t(sizeOfNilPointer)))[:count:count],
+		payloads:      (*[1 << 26]unsafe.Pointer)(C.calloc(size, C.size_t(sizeOfNilPointer)))[:count:count],
+		indicators:    (*[1 << 26]unsafe.Pointer)(C.calloc(size, C.size_t(sizeOfNilPointer)))[:count:count],
+		ids:           (*[1 << 26]*C.OCIRaw)(C.calloc(size, C.size_t(sizeOfNilPointer)))[:count:count],
+		rawIndicators: (*[1 << 26]C.OCIInd)(C.calloc(size, C.sizeof_OCIInd))[:count:count],
+	}
+}
+
+// freePayloads frees the message properties, payloads, message ids, and the payload arrays
+func (queue *Queue) freePayloads(payloads *queuePayloads) {
+	conn := queue.conn
+	for i := 0; i < payloads.count; i++ {
+		if payloads.properties[i] != nil {
+			C.OCIDescriptorFree(unsafe.Pointer(payloads.properties[i]), C.OCI_DTYPE_AQMSG_PROPERTIES)
+		}
+		if payloads.ids[i] != nil {
+			C.OCIRawResize(conn.env, conn.errHandle, 0, &payloads.ids[i])
+		}
+		if payloads.payloads[i] != nil {
+			if queue.attributes == nil {
+				C.OCIRawResize(conn.env, conn.errHandle, 0, (**C.OCIRaw)(unsafe.Pointer(&payloads.payloads[i])))
+			} else {
+				C.OCIObjectFree(conn.env, conn.errHandle, payloads.payloads[i], C.OCI_OBJECTFREE_FORCE)
+			}
+		}
+	}
+
+	C.free(unsafe.Pointer(&payloads.properties[0]))
+	C.free(unsafe.Pointer(&payloads.payloads[0]))
+	C.free(unsafe.Pointer(&payloads.indicators[0]))
+	C.free(unsafe.Pointer(&payloads.ids[0]))
+	C.free(unsafe.Pointer(&payloads.rawIndicators[0]))
+}
+
+// newMessageProperties returns a message properties descriptor set from the message
+func (queue *Queue) newMessageProperties(message *Message) (*C.OCIAQMsgProperties, error) {
+	conn := queue.conn
+
+	propertiesP, _, err := conn.ociDescriptorAlloc(C.OCI_DTYPE_AQMSG_PROPERTIES, 0)
+	if err != nil {
+		return nil, err
+	}
+	properties := *propertiesP
+
+	setSb4 := func(value C.sb4, attributeType C.ub4) {
+		if err == nil {
+			err = conn.ociAttrSet(properties, C.OCI_DTYPE_AQMSG_PROPERTIES, unsafe.Pointer(&value), 0, attributeType)
+		}
+	}
+	setText := func(value string, attributeType C.ub4) {
+		if err == nil && value != "" {
+			text := cString(value)
+			err = conn.ociAttrSet(properties, C.OCI_DTYPE_AQMSG_PROPERTIES, unsafe.Pointer(text), C.ub4(len(value)), attributeType)
+			C.free(unsafe.Pointer(text))
+		}
+	}
+
+	setSb4(C.sb4(message.Priority), C.OCI_ATTR_PRIORITY)
+	setSb4(C.sb4(message.Delay/time.Second), C.OCI_ATTR_DELAY)
+	if message.Expiration > 0 {
+		setSb4(C.sb4(message.Expiration/time.Second), C.OCI_ATTR_EXPIRATION)
+	} else {
+		setSb4(C.OCI_MSG_NO_EXPIRATION, C.OCI_ATTR_EXPIRATION)
+	}
+	setText(message.Correlation, C.OCI_ATTR_CORRELATION)
+	setText(message.ExceptionQueue, C.OCI_ATTR_EXCEPTION_QUEUE)
+
+	if err != nil {
+		C.OCIDescriptorFree(properties, C.OCI_DTYPE_AQMSG_PROPERTIES)
+		return nil, err
+	}
+
+	return (*C.OCIAQMsgProperties)(properties), nil
+}
+
+// message returns the dequeued message at index i of payloads
+func (queue *Queue) message(payloads *queuePayloads, i int) (*Message, error) {
+	conn := queue.conn
+	properties := unsafe.Pointer(payloads.properties[i])
+	message := &Message{
+		ID: queue.rawBytes(payloads.ids[i]),
+	}
+
+	getSb4 := func(attributeType C.ub4) (int, error) {
+		var value C.sb4
+		_, err := conn.ociAttrGetHandle(properties, C.OCI_DTYPE_AQMSG_PROPERTIES, unsafe.Pointer(&value), attributeType)
+		return int(value), err
+	}
+	getText := func(attributeType C.ub4) (string, error) {
+		var value *C.OraText
+		size, err := conn.ociAttrGetHandle(properties, C.OCI_DTYPE_AQMSG_PROPERTIES, unsafe.Pointer(&value), attributeType)
+		if err != nil || value == nil {
+			return "", err
+		}
+		return cGoStringN(value, int(size)), nil
+	}
+
+	var err error
+	var seconds int
+	message.Priority, err = getSb4(C.OCI_ATTR_PRIORITY)
+	if err != nil {
+		return nil, err
+	}
+	seconds, err = getSb4(C.OCI_ATTR_DELAY)
+	if err != nil {
+		return nil, err
+	}
+	message.Delay = time.Duration(seconds) * time.Second
+	seconds, err = getSb4(C.OCI_ATTR_EXPIRATION)
+	if err != nil {
+		return nil, err
+	}
+	if seconds > 0 {
+		message.Expiration = time.Duration(seconds) * time.Second
+	}
+	message.Attempts, err = getSb4(C.OCI_ATTR_ATTEMPTS)
+	if err != nil {
+		return nil, err
+	}
+	message.Correlation, err = getText(C.OCI_ATTR_CORRELATION)
+	if err != nil {
+		return nil, err
+	}
+	message.ExceptionQueue, err = getText(C.OCI_ATTR_EXCEPTION_QUEUE)
+	if err != nil {
+		return nil, err
+	}
+
+	var state C.ub4
+	_, err = conn.ociAttrGetHandle(properties, C.OCI_DTYPE_AQMSG_PROPERTIES, unsafe.Pointer(&state), C.OCI_ATTR_MSG_STATE)
+	if err != nil {
+		return nil, err
+	}
+	message.State = MessageState(state)
+
+	var enqueueTime C.OCIDate
+	_, err = conn.ociAttrGetHandle(properties, C.OCI_DTYPE_AQMSG_PROPERTIES, unsafe.Pointer(&enqueueTime), C.OCI_ATTR_ENQ_TIME)
+	if err != nil {
+		return nil, err
+	}
+	message.EnqueueTime = ociDateToTime(&enqueueTime, conn.timeLocation)
+
+	var originalID *C.OCIRaw
+	_, err = conn.ociAttrGetHandle(properties, C.OCI_DTYPE_AQMSG_PROPERTIES, unsafe.Pointer(&originalID), C.OCI_ATTR_ORIGINAL_MSGID)
+	if err != nil {
+		return nil, err
+	}
+	message.OriginalID = queue.rawBytes(originalID)
+
+	payload := payloads.payloads[i]
+	if payload == nil {
+		return message, nil
+	}
+
+	if queue.attributes == nil {
+		if payloads.rawIndicators[i] != C.OCI_IND_NULL {
+			message.Raw = queue.rawBytes((*C.OCIRaw)(payload))
+		}
+		return message, nil
+	}
+
+	// the first indicator of the null structure is the null indicator of the object
+	nullStruct := payloads.indicators[i]
+	if nullStruct != nil && *(*C.OCIInd)(nullStruct) == C.OCI_IND_NULL {
+		return message, nil
+	}
+
+	message.Object = make(map[string]interface{}, len(queue.attributes))
+	for _, attribute := range queue.attributes {
+		message.Object[attribute.name], err = queue.getAttribute(payload, nullStruct, attribute)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return message, nil
+}
+
+// setPayload sets the payload and indicator at index i of payloads from the message
+func (queue *Queue) setPayload(payloads *queuePayloads, i int, message *Message) error {
+	conn := queue.conn
+
+	if queue.attributes == nil {
+		if message.Object != nil {
+			return ErrQueuePayload
+		}
+		payloads.indicators[i] = unsafe.Pointer(&payloads.rawIndicators[i])
+		if message.Raw == nil {
+			payloads.rawIndicators[i] = C.OCI_IND_NULL
+			return nil
+		}
+		raw, err := queue.newRaw(message.Raw)
+		if err != nil {
+			return err
+		}
+		payloads.payloads[i] = unsafe.Pointer(raw)
+		return nil
+	}
+
+	if message.Raw != nil {
+		return ErrQueuePayload
+	}
+
+	attributes := make(map[string]queueAttribute, len(queue.attributes))
+	for _, attribute := range queue.attributes {
+		attributes[attribute.name] = attribute
+	}
+	values := make(map[string]interface{}, len(message.Object))
+	for name, value := range message.Object {
+		name = strings.ToUpper(name)
+		if _, ok := attributes[name]; !ok {
+			return fmt.Errorf("payload type has no attribute %v", name)
+		}
+		values[name] = value
+	}
+
+	result := C.OCIObjectNew(
+		conn.env,               // environment handle
+		conn.errHandle,         // error handle
+		conn.svc,               // service context handle
+		C.OCI_TYPECODE_OBJECT,  // typecode of the type of the instance
+		queue.payloadType,      // type descriptor object of the instance
+		nil,                    // table, nil for a transient instance
+		C.OCI_DURATION_DEFAULT, // allocation duration of the instance
+		1,                      // value, true to create a value instead of a reference
+		&payloads.payloads[i],  // returns the instance
+	)
+	if result != C.OCI_SUCCESS {
+		return conn.getError(result)
+	}
+
+	result = C.OCIObjectGetInd(
+		conn.env,                // environment handle
+		conn.errHandle,          // error handle
+		payloads.payloads[i],    // the instance
+		&payloads.indicators[i], // returns the null structure of the instance
+	)
+	if result != C.OCI_SUCCESS {
+		return conn.getError(result)
+	}
+
+	for _, attribute := range queue.attributes {
+		err := queue.setAttribute(payloads.payloads[i], payloads.indicators[i], attribute, values[attribute.name])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setAttribute sets the attribute of the object instance to value
+func (queue *Queue) setAttribute(object unsafe.Pointer, nullStruct unsafe.Pointer, attribute queueAttribute, value interface{}) error {
+	conn := queue.conn
+
+	name := cString(attribute.name)
+	defer C.free(unsafe.Pointer(name))
+	names := (*C.oratext)(unsafe.Pointer(name))
+	nameLength := C.ub4(len(attribute.name))
+
+	nullStatus := C.OCIInd(C.OCI_IND_NOTNULL)
+	var attributeValue unsafe.Pointer
+	var number C.OCINumber
+	var date C.OCIDate
+
+	if value == nil {
+		nullStatus = C.OCI_IND_NULL
+	} else {
+		switch attribute.typeCode {
+		case C.OCI_TYPECODE_VARCHAR, C.OCI_TYPECODE_VARCHAR2, C.OCI_TYPECODE_CHAR, C.OCI_TYPECODE_NCHAR, C.OCI_TYPECODE_NVARCHAR2:
+			aString, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("attribute %v: %T is not a string", attribute.name, value)
+			}
+			text := cString(aString)
+			defer C.free(unsafe.Pointer(text))
+			var ociString *C.OCIString
+			result := C.OCIStringAssignText(conn.env, conn.errHandle, (*C.oratext)(unsafe.Pointer(text)), C.ub4(len(aString)), &ociString)
+			if result != C.OCI_SUCCESS {
+				return conn.getError(result)
+			}
+			defer C.OCIStringResize(conn.env, conn.errHandle, 0, &ociString)
+			attributeValue = unsafe.Pointer(ociString)
+
+		case C.OCI_TYPECODE_NUMBER, C.OCI_TYPECODE_INTEGER, C.OCI_TYPECODE_SMALLINT, C.OCI_TYPECODE_DECIMAL,
+			C.OCI_TYPECODE_FLOAT, C.OCI_TYPECODE_REAL, C.OCI_TYPECODE_DOUBLE:
+			var result C.sword
+			switch aNumber := value.(type) {
+			case int, int8, int16, int32, int64, uint8, uint16, uint32:
+				anInt := toInt64(aNumber)
+				result = C.OCINumberFromInt(conn.errHandle, unsafe.Pointer(&anInt), 8, C.OCI_NUMBER_SIGNED, &number)
+			case float32, float64:
+				aFloat := toFloat64(aNumber)
+				result = C.OCINumberFromReal(conn.errHandle, unsafe.Pointer(&aFloat), 8, &number)
+			default:
+				return fmt.Errorf("attribute %v: %T is not a number", attribute.name, value)
+			}
+			if result != C.OCI_SUCCESS {
+				return conn.getError(result)
+			}
+			attributeValue = unsafe.Pointer(&number)
+
+		case C.OCI_TYPECODE_DATE:
+			aTime, ok := value.(time.Time)
+			if !ok {
+				return fmt.Errorf("attribute %v: %T is not a time.Time", attribute.name, value)
+			}
+			date = timeToOCIDate(aTime)
+			attributeValue = unsafe.Pointer(&date)
+
+		case C.OCI_TYPECODE_RAW:
+			aBytes, ok := value.([]byte)
+			if !ok {
+				return fmt.Errorf("attribute %v: %T is not a []byte", attribute.name, value)
+			}
+			raw, err := queue.newRaw(aBytes)
+			if err != nil {
+				return err
+			}
+			defer C.OCIRawResize(conn.env, conn.errHandle, 0, &raw)
+			attributeValue = unsafe.Pointer(raw)
+
+		default:
+			return fmt.Errorf("attribute %v: type code %v is not supported", attribute.name, attribute.typeCode)
+		}
+	}
+
+	result := C.OCIObjectSetAttr(
+		conn.env,          // environment handle
+		conn.errHandle,    // error handle
+		object,            // the instance
+		nullStruct,        // the null structure of the instance
+		queue.payloadType, // type descriptor object of the instance
+		&names,            // array of attribute names
+		&nameLength,       // array of attribute name lengths
+		1,                 // number of attribute names
+		nil,               // array of indexes, not used
+		0,                 // number of indexes, not used
+		nullStatus,        // null status of the attribute value
+		nil,               // null structure of the attribute value, only for object attributes
+		attributeValue,    // the attribute value
+	)
+
+	return conn.getError(result)
+}
+
+// getAttribute returns the value of the attribute of the object instance
+func (queue *Queue) getAttribute(object unsafe.Pointer, nullStruct unsafe.Pointer, attribute queueAttribute) (interface{}, error) {
+	conn := queue.conn
+
+	name := cString(attribute.name)
+	defer C.free(unsafe.Pointer(name))
+	names := (*C.oratext)(unsafe.Pointer(name))
+	nameLength := C.ub4(len(attribute.name))
+
+	var nullStatus C.OCIInd
+	var attributeNullStruct unsafe.Pointer
+	var attributeValue unsafe.Pointer
+	var attributeType *C.OCIType
+
+	result := C.OCIObjectGetAttr(
+		conn.env,             // environment handle
+		conn.errHandle,       // error handle
+		object,               // the instance
+		nullStruct,           // the null structure of the instance
+		queue.payloadType,    // type descriptor object of the instance
+		&names,               // array of attribute names
+		&nameLength,          // array of attribute name lengths
+		1,                    // number of attribute names
+		nil,                  // array of indexes, not used
+		0,                    // number of indexes, not used
+		&nullStatus,          // returns the null status of the attribute value
+		&attributeNullStruct, // returns the null structure of the attribute value
+		&attributeValue,      // returns a pointer to the attribute value
+		&attributeType,       // returns the type descriptor object of the attribute
+	)
+	if result != C.OCI_SUCCESS {
+		return nil, conn.getError(result)
+	}
+	if nullStatus == C.OCI_IND_NULL || attributeValue == nil {
+		return nil, nil
+	}
+
+	switch attribute.typeCode {
+	case C.OCI_TYPECODE_VARCHAR, C.OCI_TYPECODE_VARCHAR2, C.OCI_TYPECODE_CHAR, C.OCI_TYPECODE_NCHAR, C.OCI_TYPECODE_NVARCHAR2:
+		ociString := *(**C.OCIString)(attributeValue)
+		return cGoStringN((*C.OraText)(unsafe.Pointer(C.OCIStringPtr(conn.env, ociString))), int(C.OCIStringSize(conn.env, ociString))), nil
+
+	case C.OCI_TYPECODE_NUMBER, C.OCI_TYPECODE_INTEGER, C.OCI_TYPECODE_SMALLINT, C.OCI_TYPECODE_DECIMAL,
+		C.OCI_TYPECODE_FLOAT, C.OCI_TYPECODE_REAL, C.OCI_TYPECODE_DOUBLE:
+		var aFloat float64
+		result = C.OCINumberToReal(conn.errHandle, (*C.OCINumber)(attributeValue), 8, unsafe.Pointer(&aFloat))
+		if result != C.OCI_SUCCESS {
+			return nil, conn.getError(result)
+		}
+		return aFloat, nil
+
+	case C.OCI_TYPECODE_DATE:
+		return ociDateToTime((*C.OCIDate)(attributeValue), conn.timeLocation), nil
+
+	case C.OCI_TYPECODE_RAW:
+		return queue.rawBytes(*(**C.OCIRaw)(attributeValue)), nil
+	}
+
+	return nil, fmt.Errorf("attribute %v: type code %v is not supported", attribute.name, attribute.typeCode)
+}
+
+// newRaw returns a new OCIRaw with the bytes, it must be freed with OCIRawResize to 0
+func (queue *Queue) newRaw(aBytes []byte) (*C.OCIRaw, error) {
+	var raw *C.OCIRaw
+	var bytesP *C.ub1
+	if len(aBytes) > 0 {
+		bytesP = (*C.ub1)(&aBytes[0])
+	}
+	result := C.OCIRawAssignBytes(queue.conn.env, queue.conn.errHandle, bytesP, C.ub4(len(aBytes)), &raw)
+	if result != C.OCI_SUCCESS {
+		return nil, queue.conn.getError(result)
+	}
+	return raw, nil
+}
+
+// rawBytes returns a copy of the bytes of an OCIRaw
+func (queue *Queue) rawBytes(raw *C.OCIRaw) []byte {
+	if raw == nil {
+		return nil
+	}
+	size := C.OCIRawSize(queue.conn.env, raw)
+	if size == 0 {
+		return []byte{}
+	}
+	return C.GoBytes(unsafe.Pointer(C.OCIRawPtr(queue.conn.env, raw)), C.int(size))
+}
+
+// ociTypeByName calls OCITypeByName then returns the type descriptor object.
+// An empty schema means the current schema.
+func (conn *Conn) ociTypeByName(ctx context.Context, schema string, name string) (*C.OCIType, error) {
+	var schemaP *C.OraText
+	if schema != "" {
+		schemaP = cString(schema)
+		defer C.free(unsafe.Pointer(schemaP))
+	}
+	nameP := cString(name)
+	defer C.free(unsafe.Pointer(nameP))
+
+	var tdo *C.OCIType
+	done := conn.ociBreakOnDone(ctx)
+	result := C.OCITypeByName(
+		conn.env,               // environment handle
+		conn.errHandle,         // error handle
+		conn.svc,               // service context handle
+		schemaP,                // schema name of the type, nil means the current schema
+		C.ub4(len(schema)),     // length of the schema name
+		nameP,                  // name of the type
+		C.ub4(len(name)),       // length of the name
+		nil,                    // version name, not used
+		0,                      // length of the version name
+		C.OCI_DURATION_SESSION, // pin duration
+		C.OCI_TYPEGET_ALL,      // get the type and its attributes
+		&tdo,                   // returns the type descriptor object
+	)
+	closeDone(done)
+	if result != C.OCI_SUCCESS {
+		return nil, conn.getError(result)
+	}
+
+	return tdo, nil
+}
+
+// describeTypeAttributes returns the attributes of the object type name
+func (conn *Conn) describeTypeAttributes(ctx context.Context, name string) ([]queueAttribute, error) {
+	describeHandle, param, err := conn.ociDescribeAny(ctx, name, C.OCI_PTYPE_TYPE)
+	if err != nil {
+		return nil, err
+	}
+	defer C.OCIHandleFree(unsafe.Pointer(describeHandle), C.OCI_HTYPE_DESCRIBE)
+
+	var numAttributes C.ub2
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&numAttributes), C.OCI_ATTR_NUM_TYPE_ATTRS)
+	if err != nil {
+		return nil, err
+	}
+
+	var list *C.OCIParam
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&list), C.OCI_ATTR_LIST_TYPE_ATTRS)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := make([]queueAttribute, int(numAttributes))
+	for i := range attributes {
+		var attributeParam *C.OCIParam
+		attributeParam, err = conn.ociParamGetList(list, C.ub4(i+1))
+		if err != nil {
+			return nil, err
+		}
+		attributes[i].name, err = conn.ociAttrGetString(attributeParam, C.OCI_ATTR_NAME)
+		if err != nil {
+			return nil, err
+		}
+		_, err = conn.ociAttrGet(attributeParam, unsafe.Pointer(&attributes[i].typeCode), C.OCI_ATTR_TYPECODE)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return attributes, nil
+}
+
+// commitOutsideTransaction commits when the connection is not in a transaction and autocommit is enabled
+func (conn *Conn) commitOutsideTransaction() error {
+	if !conn.autocommit() {
+		return nil
+	}
+	return conn.ociTransCommit(conn.commitFlags)
+}
+
+// ociDateToTime returns the time of an OCIDate in location
+func ociDateToTime(date *C.OCIDate, location *time.Location) time.Time {
+	return time.Date(int(date.OCIDateYYYY), time.Month(date.OCIDateMM), int(date.OCIDateDD),
+		int(date.OCIDateTime.OCITimeHH), int(date.OCIDateTime.OCITimeMI), int(date.OCIDateTime.OCITimeSS), 0, location)
+}
+
+// timeToOCIDate returns the OCIDate of a time, the time zone is truncated
+func timeToOCIDate(aTime time.Time) C.OCIDate {
+	var date C.OCIDate
+	date.OCIDateYYYY = C.sb2(aTime.Year())
+	date.OCIDateMM = C.ub1(aTime.Month())
+	date.OCIDateDD = C.ub1(aTime.Day())
+	date.OCIDateTime.OCITimeHH = C.ub1(aTime.Hour())
+	date.OCIDateTime.OCITimeMI = C.ub1(aTime.Minute())
+	date.OCIDateTime.OCITimeSS = C.ub1(aTime.Second())
+	return date
+}
+
+// toInt64 converts a signed or small unsigned integer to int64
+func toInt64(value interface{}) int64 {
+	switch value := value.(type) {
+	case int:
+		return int64(value)
+	case int8:
+		return int64(value)
+	case int16:
+		return int64(value)
+	case int32:
+		return int64(value)
+	case int64:
+		return value
+	case uint8:
+		return int64(value)
+	case uint16:
+		return int64(value)
+	case uint32:
+		return int64(value)
+	}
+	return 0
+}
+
+// toFloat64 converts a float32 or float64 to float64
+func toFloat64(value interface{}) float64 {
+	switch value := value.(type) {
+	case float32:
+		return float64(value)
+	case float64:
+		return value
+	}
+	return 0
+}
+
+// dequeueWaitSeconds returns the dequeue wait in seconds, rounded up so a wait of less than a second still waits,
+// and capped to the max sb4
+func dequeueWaitSeconds(wait time.Duration) int64 {
+	if wait <= 0 {
+		return 0
+	}
+	if wait > math.MaxInt32*time.Second {
+		return math.MaxInt32
+	}
+	return int64((wait + time.Second - 1) / time.Second)
+}
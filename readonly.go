@@ -0,0 +1,83 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// WithReadOnly returns a context that runs statements prepared with it on a reader session
+// when the connection is from a Connector with ReaderHosts.
+// Statements prepared in a transaction that is not read-only still run on the connection.
+func WithReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyKey{}, true)
+}
+
+// readOnlyConn returns the connection to prepare a statement with ctx on,
+// which is the reader session during a read-only transaction or for a WithReadOnly context outside a transaction.
+// A reader session returns itself, so statements forwarded to it with the same context are not routed again.
+func (conn *Conn) readOnlyConn(ctx context.Context) (*Conn, error) {
+	if conn.isReader {
+		return conn, nil
+	}
+	if conn.readerTx {
+		return conn.reader, nil
+	}
+	if readOnly, _ := ctx.Value(readOnlyKey{}).(bool); !readOnly || conn.inTransaction {
+		return conn, nil
+	}
+	return conn.readerConn(ctx)
+}
+
+// readerConn returns the reader session of the connection, opening it if needed.
+// Returns the connection itself if it is a reader session or its connector has no ReaderHosts.
+func (conn *Conn) readerConn(ctx context.Context) (*Conn, error) {
+	if conn.isReader || conn.connector == nil || len(conn.connector.ReaderHosts) == 0 {
+		return conn, nil
+	}
+	if conn.reader != nil {
+		if !conn.reader.closed && !conn.reader.badConn {
+			return conn.reader, nil
+		}
+		conn.reader.Close()
+		conn.reader = nil
+	}
+
+	reader, err := conn.connector.connect(ctx, conn.connector.ReaderHosts, &conn.connector.readersFailed, true)
+	if err != nil {
+		return nil, err
+	}
+	conn.reader = reader
+	return reader, nil
+}
+
+// beginReadOnly starts a read-only transaction on the reader session of the connection,
+// or on the connection if its connector has no ReaderHosts
+func (conn *Conn) beginReadOnly(ctx context.Context) (driver.Tx, error) {
+	reader, err := conn.readerConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if reader == conn {
+		return conn.beginTx(ctx, C.OCI_TRANS_READONLY)
+	}
+
+	tx, err := reader.beginTx(ctx, C.OCI_TRANS_READONLY)
+	if err != nil {
+		return nil, err
+	}
+	tx.primary = conn
+	conn.readerTx = true
+	return tx, nil
+}
+
+// closeReader closes the reader session of the connection
+func (conn *Conn) closeReader() {
+	if conn.reader != nil {
+		conn.reader.Close()
+		conn.reader = nil
+	}
+	conn.readerTx = false
+}
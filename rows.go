@@ -4,15 +4,18 @@ package oci8
 import "C"
 
 import (
+	"context"
 	"database/sql/driver"
 	"fmt"
 	"io"
+	"math"
 	"reflect"
 	"time"
 	"unsafe"
 )
 
-// Close closes rows
+// Close closes rows.
+// The defines are freed even if the connection is bad, then driver.ErrBadConn is returned.
 func (rows *Rows) Close() error {
 	if rows.closed {
 		return nil
@@ -20,9 +23,16 @@ func (rows *Rows) Close() error {
 
 	rows.closed = true
 
+	var err error
+	if rows.stmt.conn.badConn {
+		err = driver.ErrBadConn
+	}
+	rows.hookFetchDone(err)
+
+	rows.stmt.conn.addMemory(-definesMemory(rows.defines))
 	freeDefines(rows.defines)
 
-	return nil
+	return err
 }
 
 // Columns returns column names
@@ -36,28 +46,53 @@ func (rows *Rows) Columns() []string {
 
 // Next gets next row
 func (rows *Rows) Next(dest []driver.Value) error {
+	return rows.fetch(dest, C.OCI_FETCH_NEXT, 0)
+}
+
+// fetch calls OCIStmtFetch2 with orientation and offset then sets dest to the fetched row
+func (rows *Rows) fetch(dest []driver.Value, orientation C.ub2, offset C.sb4) (err error) {
 	if rows.closed {
 		return nil
 	}
 
+	if rows.hookCtx != nil {
+		start := time.Now()
+		defer func() {
+			rows.fetchDuration += time.Since(start)
+			switch err {
+			case nil:
+				rows.fetched++
+			case io.EOF:
+				rows.hookFetchDone(nil)
+			default:
+				rows.hookFetchDone(err)
+			}
+		}()
+	}
+
 	if rows.stmt.ctx.Err() != nil {
 		return rows.stmt.ctx.Err()
 	}
 
 	done := rows.stmt.conn.ociBreakOnDone(rows.stmt.ctx)
 	result := C.OCIStmtFetch2(
-		rows.stmt.stmt,
-		rows.stmt.conn.errHandle,
-		1,
-		C.OCI_FETCH_NEXT,
-		0,
-		C.OCI_DEFAULT)
+		rows.stmt.stmt,           // statement handle
+		rows.stmt.conn.errHandle, // error handle
+		1,                        // number of rows to be fetched from the current position
+		orientation,              // OCI_FETCH_NEXT, or for scrollable cursors OCI_FETCH_FIRST, OCI_FETCH_LAST, OCI_FETCH_PRIOR, OCI_FETCH_ABSOLUTE, OCI_FETCH_RELATIVE
+		offset,                   // offset for OCI_FETCH_ABSOLUTE and OCI_FETCH_RELATIVE
+		C.OCI_DEFAULT,            // mode
+	)
 	closeDone(done)
+	rows.stmt.conn.addRoundTrips(1)
 	if result == C.OCI_NO_DATA {
 		return io.EOF
 	} else if result != C.OCI_SUCCESS && result != C.OCI_SUCCESS_WITH_INFO {
 		return rows.stmt.conn.getError(result)
 	}
+	rows.stmt.conn.updateStats(func(stats *ConnStats) {
+		stats.RowsFetched++
+	})
 
 	for i := range dest {
 		if *rows.defines[i].indicator == -1 { // Null
@@ -211,95 +246,138 @@ func (rows *Rows) Next(dest []driver.Value) error {
 	return nil
 }
 
+// QueryScrollable runs a query on conn using a scrollable cursor.
+// Args are bound by position unless passed as sql.Named.
+// The returned ScrollableRows must be closed.
+func QueryScrollable(ctx context.Context, conn driver.Conn, query string, args ...interface{}) (*ScrollableRows, error) {
+	oci8Conn, err := toConn(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	driverStmt, err := oci8Conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	stmt := driverStmt.(*Stmt)
+	stmt.scrollable = true
+
+	driverRows, err := stmt.QueryContext(ctx, toNamedValues(args))
+	if err != nil {
+		stmt.Close()
+		return nil, err
+	}
+
+	return &ScrollableRows{Rows: driverRows.(*Rows)}, nil
+}
+
+// Close closes the scrollable rows and the statement
+func (rows *ScrollableRows) Close() error {
+	err := rows.Rows.Close()
+	stmtErr := rows.Rows.stmt.Close()
+	if err != nil {
+		return err
+	}
+	return stmtErr
+}
+
+// First gets the first row
+func (rows *ScrollableRows) First(dest []driver.Value) error {
+	return rows.fetch(dest, C.OCI_FETCH_FIRST, 0)
+}
+
+// Last gets the last row
+func (rows *ScrollableRows) Last(dest []driver.Value) error {
+	return rows.fetch(dest, C.OCI_FETCH_LAST, 0)
+}
+
+// Prior gets the row before the current position
+func (rows *ScrollableRows) Prior(dest []driver.Value) error {
+	return rows.fetch(dest, C.OCI_FETCH_PRIOR, 0)
+}
+
+// Absolute gets row number n, the first row is 1
+func (rows *ScrollableRows) Absolute(n int, dest []driver.Value) error {
+	return rows.fetch(dest, C.OCI_FETCH_ABSOLUTE, C.sb4(n))
+}
+
+// Relative gets the row n rows from the current position, n can be negative
+func (rows *ScrollableRows) Relative(n int, dest []driver.Value) error {
+	return rows.fetch(dest, C.OCI_FETCH_RELATIVE, C.sb4(n))
+}
+
+// Position returns the row number of the current position, the first row is 1.
+// Returns 0 before the first fetch.
+func (rows *ScrollableRows) Position() (int, error) {
+	var position C.ub4
+	_, err := rows.stmt.ociAttrGet(unsafe.Pointer(&position), C.OCI_ATTR_CURRENT_POSITION)
+	if err != nil {
+		return 0, err
+	}
+	return int(position), nil
+}
+
 // ColumnTypeDatabaseTypeName implement RowsColumnTypeDatabaseTypeName.
+// Returns the Oracle type name, like VARCHAR2, NUMBER, or DATE.
 func (rows *Rows) ColumnTypeDatabaseTypeName(i int) string {
 	if len(rows.defines) < i+1 {
 		return ""
 	}
 
-	switch rows.defines[i].dataType {
-	case C.SQLT_CHR:
-		return "SQLT_CHR"
-	case C.SQLT_NUM:
-		return "SQLT_NUM"
-	case C.SQLT_INT:
-		return "SQLT_INT"
-	case C.SQLT_FLT:
-		return "SQLT_FLT"
-	case C.SQLT_STR:
-		return "SQLT_STR"
-	case C.SQLT_VNU:
-		return "SQLT_VNU"
-	case C.SQLT_LNG:
-		return "SQLT_LNG"
-	case C.SQLT_VCS:
-		return "SQLT_VCS"
-	case C.SQLT_DAT:
-		return "SQLT_DAT"
-	case C.SQLT_VBI:
-		return "SQLT_VBI"
-	case C.SQLT_BFLOAT:
-		return "SQLT_BFLOAT"
-	case C.SQLT_BDOUBLE:
-		return "SQLT_BDOUBLE"
-	case C.SQLT_BIN:
-		return "SQLT_BIN"
-	case C.SQLT_LBI:
-		return "SQLT_LBI"
-	case C.SQLT_UIN:
-		return "SQLT_UIN"
-	case C.SQLT_LVC:
-		return "SQLT_LVC"
-	case C.SQLT_LVB:
-		return "SQLT_LVB"
-	case C.SQLT_AFC:
-		return "SQLT_AFC"
-	case C.SQLT_AVC:
-		return "SQLT_AVC"
-	case C.SQLT_RDD:
-		return "SQLT_RDD"
-	case C.SQLT_NTY:
-		return "SQLT_NTY"
-	case C.SQLT_REF:
-		return "SQLT_REF"
-	case C.SQLT_CLOB:
-		return "SQLT_CLOB"
-	case C.SQLT_BLOB:
-		return "SQLT_BLOB"
-	case C.SQLT_FILE:
-		return "SQLT_FILE"
-	case C.SQLT_VST:
-		return "SQLT_VST"
-	case C.SQLT_ODT:
-		return "SQLT_ODT"
-	case C.SQLT_DATE:
-		return "SQLT_DATE"
-	case C.SQLT_TIMESTAMP:
-		return "SQLT_TIMESTAMP"
-	case C.SQLT_TIMESTAMP_TZ:
-		return "SQLT_TIMESTAMP_TZ"
-	case C.SQLT_INTERVAL_YM:
-		return "SQLT_INTERVAL_YM"
-	case C.SQLT_INTERVAL_DS:
-		return "SQLT_INTERVAL_DS"
-	case C.SQLT_TIMESTAMP_LTZ:
-		return "SQLT_TIMESTAMP_LTZ"
-	}
-	return ""
+	return rows.defines[i].column.DatabaseTypeName
 }
 
-// ColumnTypeLength is returning OCI_ATTR_DATA_SIZE, which is max data size in bytes.
-// Note this is not returing length of the column type, like the 20 in FLOAT(20), which is what is normally expected.
-// TODO: Should / can it be changed to return length of the column type?
+// ColumnTypeLength implement RowsColumnTypeLength.
+// Returns the declared length in characters for character types, the max size in bytes for RAW,
+// and math.MaxInt64 for LONG, LONG RAW, and LOB types.
 func (rows *Rows) ColumnTypeLength(i int) (int64, bool) {
 	if len(rows.defines) < i+1 {
 		return 0, false
 	}
 
-	if rows.defines[i].dataType == C.SQLT_AFC {
-		return int64(rows.defines[i].maxSize / 2), true
+	column := rows.defines[i].column
+	switch C.ub2(column.DataType) {
+	case C.SQLT_CHR, C.SQLT_VCS, C.SQLT_AFC:
+		return int64(column.CharLength), true
+	case C.SQLT_BIN:
+		return int64(column.Size), true
+	case C.SQLT_LNG, C.SQLT_LBI, C.SQLT_CLOB, C.SQLT_BLOB:
+		return math.MaxInt64, true
+	}
+	return 0, false
+}
+
+// ColumnTypeNullable implement RowsColumnTypeNullable.
+func (rows *Rows) ColumnTypeNullable(i int) (bool, bool) {
+	if len(rows.defines) < i+1 {
+		return false, false
+	}
+
+	return rows.defines[i].column.Nullable, true
+}
+
+// ColumnTypePrecisionScale implement RowsColumnTypePrecisionScale.
+// Only applies to NUMBER and FLOAT. An unlimited precision or scale returns math.MaxInt64,
+// like NUMBER without precision, and a FLOAT returns its binary precision.
+func (rows *Rows) ColumnTypePrecisionScale(i int) (int64, int64, bool) {
+	if len(rows.defines) < i+1 {
+		return 0, 0, false
+	}
+
+	column := rows.defines[i].column
+	switch C.ub2(column.DataType) {
+	case C.SQLT_NUM, C.SQLT_VNU:
+		precision := int64(column.Precision)
+		if precision == 0 {
+			precision = math.MaxInt64
+		}
+		scale := int64(column.Scale)
+		if scale == -127 {
+			scale = math.MaxInt64
+		}
+		return precision, scale, true
 	}
-	return int64(rows.defines[i].maxSize), true
+	return 0, 0, false
 }
 
 // ColumnTypeScanType implement RowsColumnTypeScanType.
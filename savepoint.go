@@ -0,0 +1,162 @@
+package oci8
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+)
+
+// Savepoint sets the savepoint name in the transaction.
+// Setting an existing savepoint name moves the savepoint to the current point of the transaction.
+func (tx *Tx) Savepoint(ctx context.Context, name string) error {
+	return tx.conn.savepoint(ctx, name)
+}
+
+// RollbackTo rolls back the transaction to the savepoint name.
+// The savepoint is kept and savepoints set after it are removed.
+func (tx *Tx) RollbackTo(ctx context.Context, name string) error {
+	return tx.conn.rollbackTo(ctx, name)
+}
+
+// Release removes the savepoint name and savepoints set after it.
+// Oracle has no release savepoint statement, so the changes made after the savepoint are kept in the transaction.
+func (tx *Tx) Release(name string) error {
+	return tx.conn.releaseSavepoint(name)
+}
+
+// Savepoints returns the savepoint names of the transaction, oldest first
+func (tx *Tx) Savepoints() []string {
+	return append([]string(nil), tx.conn.savepoints...)
+}
+
+// Savepoint sets the savepoint name in the current transaction of conn, see Tx Savepoint
+func Savepoint(ctx context.Context, conn driver.Conn, name string) error {
+	oci8Conn, err := toConn(conn)
+	if err != nil {
+		return err
+	}
+	return oci8Conn.savepoint(ctx, name)
+}
+
+// RollbackToSavepoint rolls back the current transaction of conn to the savepoint name, see Tx RollbackTo
+func RollbackToSavepoint(ctx context.Context, conn driver.Conn, name string) error {
+	oci8Conn, err := toConn(conn)
+	if err != nil {
+		return err
+	}
+	return oci8Conn.rollbackTo(ctx, name)
+}
+
+// ReleaseSavepoint removes the savepoint name from the current transaction of conn, see Tx Release
+func ReleaseSavepoint(conn driver.Conn, name string) error {
+	oci8Conn, err := toConn(conn)
+	if err != nil {
+		return err
+	}
+	return oci8Conn.releaseSavepoint(name)
+}
+
+// savepoint executes SAVEPOINT then tracks the savepoint name
+func (conn *Conn) savepoint(ctx context.Context, name string) error {
+	if conn.autocommit() {
+		return ErrNotInTransaction
+	}
+	conn.clearEndedSavepoints()
+	name, err := savepointName(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.execSimple(ctx, "savepoint "+name)
+	if err != nil {
+		return err
+	}
+
+	if index := conn.savepointIndex(name); index > -1 {
+		conn.savepoints = append(conn.savepoints[:index], conn.savepoints[index+1:]...)
+	}
+	conn.savepoints = append(conn.savepoints, name)
+
+	return nil
+}
+
+// rollbackTo executes ROLLBACK TO SAVEPOINT then removes the savepoints after it
+func (conn *Conn) rollbackTo(ctx context.Context, name string) error {
+	if conn.autocommit() {
+		return ErrNotInTransaction
+	}
+	conn.clearEndedSavepoints()
+	name, err := savepointName(name)
+	if err != nil {
+		return err
+	}
+	index := conn.savepointIndex(name)
+	if index < 0 {
+		return ErrSavepointNotFound
+	}
+
+	_, err = conn.execSimple(ctx, "rollback to savepoint "+name)
+	if err != nil {
+		return err
+	}
+
+	conn.savepoints = conn.savepoints[:index+1]
+
+	return nil
+}
+
+// releaseSavepoint removes the savepoint and the savepoints after it
+func (conn *Conn) releaseSavepoint(name string) error {
+	if conn.autocommit() {
+		return ErrNotInTransaction
+	}
+	conn.clearEndedSavepoints()
+	name, err := savepointName(name)
+	if err != nil {
+		return err
+	}
+	index := conn.savepointIndex(name)
+	if index < 0 {
+		return ErrSavepointNotFound
+	}
+
+	conn.savepoints = conn.savepoints[:index]
+
+	return nil
+}
+
+// clearEndedSavepoints clears the savepoints when the transaction was ended by the server,
+// like by DDL, a commit statement, or PL/SQL that commits
+func (conn *Conn) clearEndedSavepoints() {
+	if len(conn.savepoints) > 0 && !conn.InTransaction() {
+		conn.savepoints = nil
+	}
+}
+
+// savepointIndex returns the index of the savepoint name, or -1 if not found
+func (conn *Conn) savepointIndex(name string) int {
+	for i := range conn.savepoints {
+		if conn.savepoints[i] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// savepointName returns the upper case savepoint name if it is a valid unquoted identifier.
+// The name must start with a letter, then only contain letters, digits, _, $, and #, and be at most 128 bytes.
+func savepointName(name string) (string, error) {
+	if len(name) < 1 || len(name) > 128 {
+		return "", ErrSavepointName
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		case i > 0 && ((c >= '0' && c <= '9') || c == '_' || c == '$' || c == '#'):
+		default:
+			return "", ErrSavepointName
+		}
+	}
+	return strings.ToUpper(name), nil
+}
@@ -0,0 +1,79 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"unsafe"
+)
+
+// ResetSession is called by database/sql before the connection is reused.
+// Rolls back a transaction left in progress, clears the module, action, and client identifier,
+// and when the DSN has reset_packages=true, calls DBMS_SESSION.RESET_PACKAGE.
+// The reader session of the connection is reset the same way, or closed if it can not be reset.
+// Returns driver.ErrBadConn if the connection can not be reset, so it is discarded.
+func (conn *Conn) ResetSession(ctx context.Context) error {
+	// the call timeout left by the last call, which can be the little time left until its deadline, is not used for the reset
+	conn.setCallTimeout(ctx)
+
+	if !conn.IsValid() {
+		return driver.ErrBadConn
+	}
+
+	if conn.inTransaction || conn.InTransaction() {
+		err := conn.ociTransRollback()
+		if err != nil {
+			conn.logError(ctx, "ResetSession rollback error", err)
+			return driver.ErrBadConn
+		}
+	}
+	conn.inTransaction = false
+	conn.savepoints = nil
+
+	// the attributes are sent to the server with the next round trip
+	for _, attributeType := range []C.ub4{C.OCI_ATTR_MODULE, C.OCI_ATTR_ACTION, C.OCI_ATTR_CLIENT_IDENTIFIER} {
+		var empty C.OraText
+		err := conn.ociAttrSet(unsafe.Pointer(conn.usrSession), C.OCI_HTYPE_SESSION, unsafe.Pointer(&empty), 0, attributeType)
+		if err != nil {
+			conn.logError(ctx, "ResetSession attribute error", err)
+			return driver.ErrBadConn
+		}
+	}
+
+	if conn.resetPackages {
+		_, err := conn.execSimple(ctx, "begin dbms_session.reset_package; end;")
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			conn.logError(ctx, "ResetSession reset package error", err)
+			return driver.ErrBadConn
+		}
+	}
+
+	conn.readerTx = false
+	if conn.reader != nil && conn.reader.ResetSession(ctx) != nil {
+		conn.closeReader()
+	}
+
+	return nil
+}
+
+// IsValid is called by database/sql before the connection is reused or returned to the pool.
+// Returns false if the connection is closed, an error showed the connection is no longer usable,
+// or the server handle is not connected. Does not cause a round trip.
+func (conn *Conn) IsValid() bool {
+	if conn.closed || conn.badConn {
+		return false
+	}
+
+	var status C.ub4
+	_, err := conn.ociAttrGetHandle(unsafe.Pointer(conn.srv), C.OCI_HTYPE_SERVER, unsafe.Pointer(&status), C.OCI_ATTR_SERVER_STATUS)
+	if err != nil {
+		return false
+	}
+
+	return status == C.OCI_SERVER_NORMAL
+}
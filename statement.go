@@ -47,9 +47,9 @@ func (stmt *Stmt) Close() error {
 	return stmt.conn.getError(result)
 }
 
-// NumInput returns the number of input
+// NumInput returns the number of inputs, or -1 if it is not known
 func (stmt *Stmt) NumInput() int {
-	return -1
+	return stmt.numInput
 }
 
 // CheckNamedValue checks a named value
@@ -63,6 +63,10 @@ func (stmt *Stmt) CheckNamedValue(namedValue *driver.NamedValue) error {
 
 // bindValues binds the values to the stmt
 func (stmt *Stmt) bindValues(values []driver.Value, namedValues []driver.NamedValue) ([]bindStruct, error) {
+	if stmt.conn.levelLogger != nil {
+		stmt.logBinds = stmt.conn.logBindValues(values, namedValues)
+	}
+
 	if len(values) == 0 && len(namedValues) == 0 {
 		return nil, nil
 	}
@@ -380,6 +384,22 @@ func (stmt *Stmt) bindValues(values []driver.Value, namedValues []driver.NamedVa
 	return binds, nil
 }
 
+// toNamedValues converts args to named values.
+// A sql.NamedArg is bound by name, anything else by position.
+func toNamedValues(args []interface{}) []driver.NamedValue {
+	namedValues := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		namedValues[i].Ordinal = i + 1
+		if namedArg, ok := arg.(sql.NamedArg); ok {
+			namedValues[i].Name = namedArg.Name
+			namedValues[i].Value = namedArg.Value
+		} else {
+			namedValues[i].Value = arg
+		}
+	}
+	return namedValues
+}
+
 // Query runs a query
 func (stmt *Stmt) Query(values []driver.Value) (driver.Rows, error) {
 	stmt.ctx = context.Background()
@@ -403,11 +423,32 @@ func (stmt *Stmt) QueryContext(ctx context.Context, namedValues []driver.NamedVa
 }
 
 // query runs a query with context
-func (stmt *Stmt) query(binds []bindStruct) (driver.Rows, error) {
+func (stmt *Stmt) query(binds []bindStruct) (driverRows driver.Rows, err error) {
 	defer freeBinds(binds)
+	bindsBytes := bindsMemory(binds)
+	stmt.conn.addMemory(bindsBytes)
+	defer stmt.conn.addMemory(-bindsBytes)
+
+	if stmt.conn.badConn {
+		return nil, driver.ErrBadConn
+	}
+
+	if stmt.conn.hooks != nil {
+		hookEnd := stmt.hookExec(len(binds))
+		defer func() {
+			hookEnd(driverRows, err)
+		}()
+	}
+
+	if stmt.conn.levelLogger != nil {
+		logEnd := stmt.logStatement("query")
+		defer func() {
+			logEnd(err)
+		}()
+	}
 
 	var stmtType C.ub2
-	_, err := stmt.ociAttrGet(unsafe.Pointer(&stmtType), C.OCI_ATTR_STMT_TYPE)
+	_, err = stmt.ociAttrGet(unsafe.Pointer(&stmtType), C.OCI_ATTR_STMT_TYPE)
 	if err != nil {
 		return nil, err
 	}
@@ -437,9 +478,12 @@ func (stmt *Stmt) query(binds []bindStruct) (driver.Rows, error) {
 	}
 
 	mode := C.ub4(C.OCI_DEFAULT)
-	if !stmt.conn.inTransaction {
+	if stmt.conn.autocommit() {
 		mode = mode | C.OCI_COMMIT_ON_SUCCESS
 	}
+	if stmt.scrollable {
+		mode = mode | C.OCI_STMT_SCROLLABLE_READONLY
+	}
 
 	if stmt.ctx.Err() != nil {
 		return nil, stmt.ctx.Err()
@@ -451,6 +495,9 @@ func (stmt *Stmt) query(binds []bindStruct) (driver.Rows, error) {
 	if err != nil && err != ErrOCISuccessWithInfo {
 		return nil, err
 	}
+	if err == ErrOCISuccessWithInfo && stmt.conn.levelLogger != nil {
+		stmt.logSuccessWithInfo("query")
+	}
 
 	var defines []defineStruct
 	defines, err = stmt.makeDefines()
@@ -463,6 +510,8 @@ func (stmt *Stmt) query(binds []bindStruct) (driver.Rows, error) {
 		return nil, stmt.ctx.Err()
 	}
 
+	stmt.conn.addMemory(definesMemory(defines))
+
 	rows := &Rows{
 		stmt:    stmt,
 		defines: defines,
@@ -495,6 +544,12 @@ func (stmt *Stmt) makeDefines() ([]defineStruct, error) {
 		}
 		defer C.OCIDescriptorFree(unsafe.Pointer(param), C.OCI_DTYPE_PARAM)
 
+		defines[i].column, err = stmt.conn.describeColumn(param)
+		if err != nil {
+			freeDefines(defines)
+			return nil, err
+		}
+
 		var dataType C.ub2 // external datatype of the column: https://docs.oracle.com/cd/E11882_01/appdev.112/e10646/oci03typ.htm#CEGIEEJI
 		_, err = stmt.conn.ociAttrGet(param, unsafe.Pointer(&dataType), C.OCI_ATTR_DATA_TYPE)
 		if err != nil {
@@ -742,12 +797,38 @@ func (stmt *Stmt) ExecContext(ctx context.Context, namedValues []driver.NamedVal
 	return stmt.exec(binds)
 }
 
-func (stmt *Stmt) exec(binds []bindStruct) (driver.Result, error) {
+func (stmt *Stmt) exec(binds []bindStruct) (driverResult driver.Result, err error) {
 	defer freeBinds(binds)
+	bindsBytes := bindsMemory(binds)
+	stmt.conn.addMemory(bindsBytes)
+	defer stmt.conn.addMemory(-bindsBytes)
+
+	if stmt.conn.badConn {
+		return nil, driver.ErrBadConn
+	}
+
+	if stmt.conn.hooks != nil {
+		hookEnd := stmt.hookExec(len(binds))
+		defer func() {
+			hookEnd(driverResult, err)
+		}()
+	}
+
+	if stmt.conn.levelLogger != nil {
+		logEnd := stmt.logStatement("exec")
+		defer func() {
+			logEnd(err)
+		}()
+	}
 
+	// OCI_COMMIT_ON_SUCCESS always waits for the redo, so other commit modes commit after the execute
 	mode := C.ub4(C.OCI_DEFAULT)
-	if stmt.conn.inTransaction == false {
-		mode = mode | C.OCI_COMMIT_ON_SUCCESS
+	commitFlags := C.ub4(C.OCI_DEFAULT)
+	if stmt.conn.autocommit() {
+		commitFlags = stmt.conn.commitFlagsFromContext(stmt.ctx)
+		if commitFlags == C.OCI_DEFAULT {
+			mode = mode | C.OCI_COMMIT_ON_SUCCESS
+		}
 	}
 
 	if stmt.ctx.Err() != nil {
@@ -755,11 +836,21 @@ func (stmt *Stmt) exec(binds []bindStruct) (driver.Result, error) {
 	}
 
 	done := stmt.conn.ociBreakOnDone(stmt.ctx)
-	err := stmt.ociStmtExecute(1, mode)
+	err = stmt.ociStmtExecute(1, mode)
 	closeDone(done)
 	if err != nil && err != ErrOCISuccessWithInfo {
 		return nil, err
 	}
+	if err == ErrOCISuccessWithInfo && stmt.conn.levelLogger != nil {
+		stmt.logSuccessWithInfo("exec")
+	}
+
+	if commitFlags != C.OCI_DEFAULT {
+		err = stmt.conn.ociTransCommit(commitFlags)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	result := Result{stmt: stmt}
 
@@ -775,6 +866,8 @@ func (stmt *Stmt) exec(binds []bindStruct) (driver.Result, error) {
 		return nil, err
 	}
 
+	stmt.conn.deliverServerOutput(stmt.ctx)
+
 	return &result, nil
 }
 
@@ -1035,6 +1128,10 @@ func (stmt *Stmt) ociStmtExecute(iters C.ub4, mode C.ub4) error {
 		nil,                 // This parameter is optional. If it is supplied, it must point to a descriptor of type OCI_DTYPE_SNAP.
 		mode,                // The mode: https://docs.oracle.com/cd/E11882_01/appdev.112/e10646/oci17msc001.htm#LNOCI17163
 	)
+	stmt.conn.updateStats(func(stats *ConnStats) {
+		stats.StatementsExecuted++
+		stats.RoundTrips++
+	})
 
 	if stmt.cacheKey != "" && result != C.OCI_SUCCESS && result != C.OCI_SUCCESS_WITH_INFO {
 		// drop statement from cache for all errors when caching is enabled
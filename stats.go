@@ -0,0 +1,82 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"sync/atomic"
+)
+
+// Stats returns the statistics of the connection
+func (conn *Conn) Stats() ConnStats {
+	stats := conn.stats
+	stats.StatementCacheSize = int(conn.stmtCacheSize)
+	stats.Breaks = atomic.LoadUint64(&conn.breaks)
+	return stats
+}
+
+// updateStats calls update with the stats of the connection, then with the stats of its Connector
+func (conn *Conn) updateStats(update func(stats *ConnStats)) {
+	update(&conn.stats)
+	if conn.connectorStats != nil {
+		conn.connectorStats.mutex.Lock()
+		update(&conn.connectorStats.stats)
+		conn.connectorStats.mutex.Unlock()
+	}
+}
+
+// addBreak adds to the number of breaks, it is called from the ociBreakDone goroutine
+func (conn *Conn) addBreak() {
+	atomic.AddUint64(&conn.breaks, 1)
+	if conn.connectorStats != nil {
+		conn.connectorStats.mutex.Lock()
+		conn.connectorStats.stats.Breaks++
+		conn.connectorStats.mutex.Unlock()
+	}
+}
+
+// setBadConn marks the connection as no longer usable
+func (conn *Conn) setBadConn() {
+	if conn.badConn {
+		return
+	}
+	conn.badConn = true
+	conn.updateStats(func(stats *ConnStats) {
+		stats.BadConns++
+	})
+}
+
+// addRoundTrips adds to the number of round trips
+func (conn *Conn) addRoundTrips(count uint64) {
+	conn.updateStats(func(stats *ConnStats) {
+		stats.RoundTrips += count
+	})
+}
+
+// addMemory adds bytes to the C memory allocated for binds and defines, bytes is negative when the memory is freed
+func (conn *Conn) addMemory(bytes int64) {
+	if bytes == 0 {
+		return
+	}
+	conn.updateStats(func(stats *ConnStats) {
+		stats.Memory += bytes
+	})
+}
+
+// bindsMemory returns the bytes of C memory allocated for binds
+func bindsMemory(binds []bindStruct) int64 {
+	var bytes int64
+	for i := range binds {
+		bytes += int64(binds[i].maxSize) + C.sizeof_ub2 + C.sizeof_sb2
+	}
+	return bytes
+}
+
+// definesMemory returns the bytes of C memory allocated for defines
+func definesMemory(defines []defineStruct) int64 {
+	var bytes int64
+	for i := range defines {
+		bytes += int64(defines[i].maxSize) + C.sizeof_ub2 + C.sizeof_sb2 + definesMemory(defines[i].subDefines)
+	}
+	return bytes
+}
@@ -0,0 +1,11 @@
+package oci8
+
+import (
+	"context"
+)
+
+// WithStatementCache returns a context that sets the statement cache options of statements prepared with it.
+// The options are only used when statement caching is enabled with the stmt_cache_size DSN parameter.
+func WithStatementCache(ctx context.Context, options StatementCacheOptions) context.Context {
+	return context.WithValue(ctx, statementCacheKey{}, options)
+}
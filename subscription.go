@@ -0,0 +1,481 @@
+package oci8
+
+/*
+#include "oci8.go.h"
+
+extern void oci8SubscriptionCallback(dvoid *ctx, OCISubscription *subscription, dvoid *payload, ub4 *payloadLength, dvoid *descriptor, ub4 mode);
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"unsafe"
+)
+
+// Event types of a ChangeEvent
+const (
+	EventNone         EventType = C.OCI_EVENT_NONE
+	EventStartup      EventType = C.OCI_EVENT_STARTUP
+	EventShutdown     EventType = C.OCI_EVENT_SHUTDOWN
+	EventShutdownAny  EventType = C.OCI_EVENT_SHUTDOWN_ANY
+	EventDropDB       EventType = C.OCI_EVENT_DROP_DB
+	EventDeregister   EventType = C.OCI_EVENT_DEREG
+	EventObjectChange EventType = C.OCI_EVENT_OBJCHANGE
+	EventQueryChange  EventType = C.OCI_EVENT_QUERYCHANGE
+)
+
+// Operations of a TableChange or RowChange
+const (
+	OperationAllRows Operation = C.OCI_OPCODE_ALLROWS
+	OperationInsert  Operation = C.OCI_OPCODE_INSERT
+	OperationUpdate  Operation = C.OCI_OPCODE_UPDATE
+	OperationDelete  Operation = C.OCI_OPCODE_DELETE
+	OperationAlter   Operation = C.OCI_OPCODE_ALTER
+	OperationDrop    Operation = C.OCI_OPCODE_DROP
+	OperationUnknown Operation = C.OCI_OPCODE_UNKNOWN
+)
+
+// Subscribe registers a Continuous Query Notification subscription on conn.
+// The connection must be opened with the events DSN parameter or Connector.Events set.
+// Queries are added to the subscription with RegisterQuery and change events are received from Events.
+// The connection must stay open until the subscription is closed.
+func Subscribe(conn driver.Conn, options SubscriptionOptions) (*Subscription, error) {
+	oci8Conn, err := toConn(conn)
+	if err != nil {
+		return nil, err
+	}
+	if !oci8Conn.enableEvents {
+		return nil, ErrEventsNotEnabled
+	}
+
+	if options.Port > 0 {
+		port := C.ub4(options.Port)
+		err = oci8Conn.ociAttrSet(unsafe.Pointer(oci8Conn.env), C.OCI_HTYPE_ENV, unsafe.Pointer(&port), 0, C.OCI_ATTR_SUBSCR_PORTNO)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if options.Address != "" {
+		address := cString(options.Address)
+		defer C.free(unsafe.Pointer(address))
+		err = oci8Conn.ociAttrSet(unsafe.Pointer(oci8Conn.env), C.OCI_HTYPE_ENV, unsafe.Pointer(address), C.ub4(len(options.Address)), C.OCI_ATTR_SUBSCR_IPADDR)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	subscription := &Subscription{
+		conn:   oci8Conn,
+		events: make(chan ChangeEvent, options.BufferSize),
+		done:   make(chan struct{}),
+	}
+
+	var handle *unsafe.Pointer
+	handle, _, err = oci8Conn.ociHandleAlloc(C.OCI_HTYPE_ERROR, 0)
+	if err != nil {
+		return nil, err
+	}
+	subscription.errHandle = (*C.OCIError)(*handle)
+
+	handle, _, err = oci8Conn.ociHandleAlloc(C.OCI_HTYPE_SUBSCRIPTION, 0)
+	if err != nil {
+		subscription.free()
+		return nil, err
+	}
+	subscription.subscription = (*C.OCISubscription)(*handle)
+
+	subscription.id = (*C.ub8)(C.malloc(C.sizeof_ub8))
+	subscriptionsMutex.Lock()
+	subscriptionNextID++
+	*subscription.id = subscriptionNextID
+	subscriptions[subscriptionNextID] = subscription
+	subscriptionsMutex.Unlock()
+
+	err = subscription.setAttributes(options)
+	if err != nil {
+		subscription.free()
+		return nil, err
+	}
+
+	oci8Conn.setCallTimeout(context.Background())
+	result := C.OCISubscriptionRegister(
+		oci8Conn.svc,               // service context handle
+		&subscription.subscription, // array of subscription handles
+		1,                          // number of subscription handles
+		oci8Conn.errHandle,         // error handle
+		C.OCI_DEFAULT,              // mode
+	)
+	if result != C.OCI_SUCCESS {
+		err = oci8Conn.getError(result)
+		subscription.free()
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// setAttributes sets the subscription handle attributes from the options
+func (subscription *Subscription) setAttributes(options SubscriptionOptions) error {
+	conn := subscription.conn
+	handle := unsafe.Pointer(subscription.subscription)
+
+	namespace := C.ub4(C.OCI_SUBSCR_NAMESPACE_DBCHANGE)
+	err := conn.ociAttrSet(handle, C.OCI_HTYPE_SUBSCRIPTION, unsafe.Pointer(&namespace), C.sizeof_ub4, C.OCI_ATTR_SUBSCR_NAMESPACE)
+	if err != nil {
+		return err
+	}
+
+	err = conn.ociAttrSet(handle, C.OCI_HTYPE_SUBSCRIPTION, unsafe.Pointer(C.oci8SubscriptionCallback), 0, C.OCI_ATTR_SUBSCR_CALLBACK)
+	if err != nil {
+		return err
+	}
+
+	err = conn.ociAttrSet(handle, C.OCI_HTYPE_SUBSCRIPTION, unsafe.Pointer(subscription.id), C.sizeof_ub8, C.OCI_ATTR_SUBSCR_CTX)
+	if err != nil {
+		return err
+	}
+
+	if options.Rowids {
+		rowids := C.boolean(1)
+		err = conn.ociAttrSet(handle, C.OCI_HTYPE_SUBSCRIPTION, unsafe.Pointer(&rowids), C.sizeof_boolean, C.OCI_ATTR_CHNF_ROWIDS)
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.Operations != 0 {
+		operations := C.ub4(options.Operations)
+		err = conn.ociAttrSet(handle, C.OCI_HTYPE_SUBSCRIPTION, unsafe.Pointer(&operations), C.sizeof_ub4, C.OCI_ATTR_CHNF_OPERATIONS)
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.ChangeLag > 0 {
+		changeLag := C.ub4(options.ChangeLag)
+		err = conn.ociAttrSet(handle, C.OCI_HTYPE_SUBSCRIPTION, unsafe.Pointer(&changeLag), C.sizeof_ub4, C.OCI_ATTR_CHNF_CHANGELAG)
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.Timeout > 0 {
+		timeout := C.ub4(options.Timeout.Seconds())
+		err = conn.ociAttrSet(handle, C.OCI_HTYPE_SUBSCRIPTION, unsafe.Pointer(&timeout), C.sizeof_ub4, C.OCI_ATTR_SUBSCR_TIMEOUT)
+		if err != nil {
+			return err
+		}
+	}
+
+	var qosFlags C.ub4
+	if options.Reliable {
+		qosFlags |= C.OCI_SUBSCR_QOS_RELIABLE
+	}
+	if options.PurgeOnNotify {
+		qosFlags |= C.OCI_SUBSCR_QOS_PURGE_ON_NTFN
+	}
+	if qosFlags != 0 {
+		err = conn.ociAttrSet(handle, C.OCI_HTYPE_SUBSCRIPTION, unsafe.Pointer(&qosFlags), C.sizeof_ub4, C.OCI_ATTR_SUBSCR_QOSFLAGS)
+		if err != nil {
+			return err
+		}
+	}
+
+	var cqQOSFlags C.ub4
+	if options.QueryLevel {
+		cqQOSFlags |= C.OCI_SUBSCR_CQ_QOS_QUERY
+		if options.BestEffort {
+			cqQOSFlags |= C.OCI_SUBSCR_CQ_QOS_BEST_EFFORT
+		}
+	}
+	if cqQOSFlags != 0 {
+		err = conn.ociAttrSet(handle, C.OCI_HTYPE_SUBSCRIPTION, unsafe.Pointer(&cqQOSFlags), C.sizeof_ub4, C.OCI_ATTR_SUBSCR_CQ_QOSFLAGS)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Events returns the channel that receives the change events.
+// The channel is closed when the subscription is closed.
+func (subscription *Subscription) Events() <-chan ChangeEvent {
+	return subscription.events
+}
+
+// RegisterQuery executes query with args and adds it, and the tables it selects from, to the subscription.
+// Returns the query id, which is the ID of the QueryChange for query level subscriptions.
+func (subscription *Subscription) RegisterQuery(ctx context.Context, query string, args ...interface{}) (uint64, error) {
+	subscription.mutex.RLock()
+	defer subscription.mutex.RUnlock()
+	if subscription.closed {
+		return 0, ErrSubscriptionClosed
+	}
+
+	driverStmt, err := subscription.conn.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	stmt := driverStmt.(*Stmt)
+	defer stmt.Close()
+
+	var stmtType C.ub2
+	_, err = stmt.ociAttrGet(unsafe.Pointer(&stmtType), C.OCI_ATTR_STMT_TYPE)
+	if err != nil {
+		return 0, err
+	}
+	if stmtType != C.OCI_STMT_SELECT {
+		return 0, errors.New("only a select query can be registered")
+	}
+
+	err = subscription.conn.ociAttrSet(unsafe.Pointer(stmt.stmt), C.OCI_HTYPE_STMT, unsafe.Pointer(subscription.subscription), 0, C.OCI_ATTR_CHNF_REGHANDLE)
+	if err != nil {
+		return 0, err
+	}
+
+	driverRows, err := stmt.QueryContext(ctx, toNamedValues(args))
+	if err != nil {
+		return 0, err
+	}
+	rows := driverRows.(*Rows)
+	defer rows.Close()
+
+	var queryID C.ub8
+	_, err = stmt.ociAttrGet(unsafe.Pointer(&queryID), C.OCI_ATTR_CQ_QUERYID)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(queryID), nil
+}
+
+// Close unregisters the subscription and closes the Events channel
+func (subscription *Subscription) Close() error {
+	// unblock a notification callback waiting to send an event before waiting on the lock
+	subscription.closeDone()
+
+	subscription.mutex.Lock()
+	if subscription.closed {
+		subscription.mutex.Unlock()
+		return nil
+	}
+	subscription.closed = true
+	subscription.mutex.Unlock()
+
+	subscription.conn.setCallTimeout(context.Background())
+	result := C.OCISubscriptionUnRegister(
+		subscription.conn.svc,       // service context handle
+		subscription.subscription,   // subscription handle
+		subscription.conn.errHandle, // error handle
+		C.OCI_DEFAULT,               // mode
+	)
+	err := subscription.conn.getError(result)
+
+	subscription.free()
+
+	return err
+}
+
+// free removes the subscription from the subscriptions, frees the handles, and closes the Events channel.
+// Must only be called once.
+func (subscription *Subscription) free() {
+	if subscription.id != nil {
+		subscriptionsMutex.Lock()
+		delete(subscriptions, *subscription.id)
+		subscriptionsMutex.Unlock()
+		C.free(unsafe.Pointer(subscription.id))
+		subscription.id = nil
+	}
+	if subscription.subscription != nil {
+		C.OCIHandleFree(unsafe.Pointer(subscription.subscription), C.OCI_HTYPE_SUBSCRIPTION)
+		subscription.subscription = nil
+	}
+	if subscription.errHandle != nil {
+		C.OCIHandleFree(unsafe.Pointer(subscription.errHandle), C.OCI_HTYPE_ERROR)
+		subscription.errHandle = nil
+	}
+
+	subscription.closeDone()
+	subscription.mutex.Lock()
+	subscription.closed = true
+	close(subscription.events)
+	subscription.mutex.Unlock()
+}
+
+// closeDone closes the done channel once
+func (subscription *Subscription) closeDone() {
+	subscription.doneOnce.Do(func() {
+		close(subscription.done)
+	})
+}
+
+// oci8SubscriptionCallback is the OCI notification callback of all subscriptions.
+// It is called on an OCI thread and the descriptor is only valid during the call.
+//
+//export oci8SubscriptionCallback
+func oci8SubscriptionCallback(ctx unsafe.Pointer, subscriptionHandle *C.OCISubscription, payload unsafe.Pointer, payloadLength *C.ub4, descriptor unsafe.Pointer, mode C.ub4) {
+	if ctx == nil || descriptor == nil {
+		return
+	}
+
+	subscriptionsMutex.Lock()
+	subscription := subscriptions[*(*C.ub8)(ctx)]
+	subscriptionsMutex.Unlock()
+	if subscription == nil {
+		return
+	}
+
+	subscription.mutex.RLock()
+	defer subscription.mutex.RUnlock()
+	if subscription.closed {
+		return
+	}
+
+	event := subscription.changeEvent(descriptor)
+
+	select {
+	case subscription.events <- event:
+	case <-subscription.done:
+	}
+}
+
+// changeEvent returns the change event of a change notification descriptor
+func (subscription *Subscription) changeEvent(descriptor unsafe.Pointer) ChangeEvent {
+	var event ChangeEvent
+
+	var eventType C.ub4
+	subscription.attrGet(descriptor, C.OCI_DTYPE_CHDES, unsafe.Pointer(&eventType), C.OCI_ATTR_CHDES_NFYTYPE)
+	event.Type = EventType(eventType)
+
+	event.Database = subscription.attrGetString(descriptor, C.OCI_DTYPE_CHDES, C.OCI_ATTR_CHDES_DBNAME)
+
+	switch event.Type {
+	case EventObjectChange:
+		var tables *C.OCIColl
+		subscription.attrGet(descriptor, C.OCI_DTYPE_CHDES, unsafe.Pointer(&tables), C.OCI_ATTR_CHDES_TABLE_CHANGES)
+		event.Tables = subscription.tableChanges(tables)
+
+	case EventQueryChange:
+		var queries *C.OCIColl
+		subscription.attrGet(descriptor, C.OCI_DTYPE_CHDES, unsafe.Pointer(&queries), C.OCI_ATTR_CHDES_QUERIES)
+		for _, queryDescriptor := range subscription.collectionElements(queries) {
+			var query QueryChange
+
+			var queryID C.ub8
+			subscription.attrGet(queryDescriptor, C.OCI_DTYPE_CQDES, unsafe.Pointer(&queryID), C.OCI_ATTR_CQDES_QUERYID)
+			query.ID = uint64(queryID)
+
+			var operation C.ub4
+			subscription.attrGet(queryDescriptor, C.OCI_DTYPE_CQDES, unsafe.Pointer(&operation), C.OCI_ATTR_CQDES_OPERATION)
+			query.Operation = EventType(operation)
+
+			var tables *C.OCIColl
+			subscription.attrGet(queryDescriptor, C.OCI_DTYPE_CQDES, unsafe.Pointer(&tables), C.OCI_ATTR_CQDES_TABLE_CHANGES)
+			query.Tables = subscription.tableChanges(tables)
+
+			event.Queries = append(event.Queries, query)
+		}
+	}
+
+	return event
+}
+
+// tableChanges returns the table changes of a collection of table change descriptors
+func (subscription *Subscription) tableChanges(tables *C.OCIColl) []TableChange {
+	var tableChanges []TableChange
+
+	for _, tableDescriptor := range subscription.collectionElements(tables) {
+		var table TableChange
+
+		table.Name = subscription.attrGetString(tableDescriptor, C.OCI_DTYPE_TABLE_CHDES, C.OCI_ATTR_CHDES_TABLE_NAME)
+
+		var operation C.ub4
+		subscription.attrGet(tableDescriptor, C.OCI_DTYPE_TABLE_CHDES, unsafe.Pointer(&operation), C.OCI_ATTR_CHDES_TABLE_OPFLAGS)
+		table.Operation = Operation(operation)
+
+		if table.Operation&OperationAllRows == 0 {
+			var rows *C.OCIColl
+			subscription.attrGet(tableDescriptor, C.OCI_DTYPE_TABLE_CHDES, unsafe.Pointer(&rows), C.OCI_ATTR_CHDES_TABLE_ROW_CHANGES)
+			for _, rowDescriptor := range subscription.collectionElements(rows) {
+				var row RowChange
+				row.Rowid = subscription.attrGetString(rowDescriptor, C.OCI_DTYPE_ROW_CHDES, C.OCI_ATTR_CHDES_ROW_ROWID)
+				subscription.attrGet(rowDescriptor, C.OCI_DTYPE_ROW_CHDES, unsafe.Pointer(&operation), C.OCI_ATTR_CHDES_ROW_OPFLAGS)
+				row.Operation = Operation(operation)
+				table.Rows = append(table.Rows, row)
+			}
+		}
+
+		tableChanges = append(tableChanges, table)
+	}
+
+	return tableChanges
+}
+
+// collectionElements returns the descriptors of a collection of descriptors
+func (subscription *Subscription) collectionElements(collection *C.OCIColl) []unsafe.Pointer {
+	if collection == nil {
+		return nil
+	}
+
+	var size C.sb4
+	result := C.OCICollSize(
+		subscription.conn.env,  // environment handle
+		subscription.errHandle, // error handle
+		collection,             // collection
+		&size,                  // returns the number of elements
+	)
+	if result != C.OCI_SUCCESS {
+		return nil
+	}
+
+	elements := make([]unsafe.Pointer, 0, int(size))
+	for i := C.sb4(0); i < size; i++ {
+		var exists C.boolean
+		var element unsafe.Pointer
+		result = C.OCICollGetElem(
+			subscription.conn.env,  // environment handle
+			subscription.errHandle, // error handle
+			collection,             // collection
+			i,                      // index of the element
+			&exists,                // false if there is no element at the index
+			&element,               // returns a pointer to the element, which is a pointer to a descriptor
+			nil,                    // returns a pointer to the null indicator, not needed
+		)
+		if result != C.OCI_SUCCESS || exists == 0 || element == nil {
+			continue
+		}
+		elements = append(elements, *(*unsafe.Pointer)(element))
+	}
+
+	return elements
+}
+
+// attrGet calls OCIAttrGet on a change notification descriptor with the subscription error handle.
+// The notification callback has no way to return an error so on error value is left unchanged.
+func (subscription *Subscription) attrGet(descriptor unsafe.Pointer, descriptorType C.ub4, value unsafe.Pointer, attributeType C.ub4) C.ub4 {
+	var size C.ub4
+
+	C.OCIAttrGet(
+		descriptor,             // Pointer to a descriptor
+		descriptorType,         // The descriptor type
+		value,                  // Pointer to the storage for an attribute value
+		&size,                  // The size of the attribute value
+		attributeType,          // The attribute type
+		subscription.errHandle, // An error handle
+	)
+
+	return size
+}
+
+// attrGetString calls attrGet then returns the text attribute as a string
+func (subscription *Subscription) attrGetString(descriptor unsafe.Pointer, descriptorType C.ub4, attributeType C.ub4) string {
+	var text *C.OraText
+	size := subscription.attrGet(descriptor, descriptorType, unsafe.Pointer(&text), attributeType)
+	if text == nil {
+		return ""
+	}
+	return cGoStringN(text, int(size))
+}
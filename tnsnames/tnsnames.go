@@ -0,0 +1,466 @@
+// Package tnsnames parses tnsnames.ora files and resolves net service name aliases to connect descriptors.
+//
+// It is pure Go, so aliases can be checked and resolved without the Oracle client.
+package tnsnames
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FileName is the file name of tnsnames.ora
+const FileName = "tnsnames.ora"
+
+type (
+	// File is a parsed tnsnames.ora, including the files included with IFILE
+	File struct {
+		// Path is the path of the file
+		Path        string
+		descriptors map[string]string
+	}
+
+	// node is a (KEYWORD=value) or (KEYWORD=(...)(...)) of a connect descriptor
+	node struct {
+		keyword  string
+		value    string
+		children []*node
+	}
+
+	// parser parses the text of a tnsnames.ora
+	parser struct {
+		path string
+		text string
+		pos  int
+	}
+)
+
+var (
+	// ErrNotFound is returned by Find when no tnsnames.ora is found
+	ErrNotFound = errors.New("tnsnames.ora not found, set TNS_ADMIN or ORACLE_HOME")
+	// ErrAliasNotFound is returned by Resolve when the alias is not in the file
+	ErrAliasNotFound = errors.New("alias not found in tnsnames.ora")
+)
+
+// Find returns the path of tnsnames.ora in the TNS_ADMIN directory, or in the network/admin directory of ORACLE_HOME
+func Find() (string, error) {
+	var dirs []string
+	if tnsAdmin := os.Getenv("TNS_ADMIN"); tnsAdmin != "" {
+		dirs = append(dirs, tnsAdmin)
+	}
+	if oracleHome := os.Getenv("ORACLE_HOME"); oracleHome != "" {
+		dirs = append(dirs, filepath.Join(oracleHome, "network", "admin"))
+	}
+
+	for _, dir := range dirs {
+		path := filepath.Join(dir, FileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", ErrNotFound
+}
+
+// Load finds then parses tnsnames.ora, see Find
+func Load() (*File, error) {
+	path, err := Find()
+	if err != nil {
+		return nil, err
+	}
+	return ParseFile(path)
+}
+
+// ParseFile parses the tnsnames.ora at path, or path/tnsnames.ora if path is a directory.
+// IFILE includes are parsed relative to the directory of the including file.
+// When an alias is defined more than once, the first definition is used.
+func ParseFile(path string) (*File, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		path = filepath.Join(path, FileName)
+	}
+
+	file := &File{Path: path, descriptors: make(map[string]string)}
+	err = file.parseFile(path, make(map[string]bool))
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Parse parses the text of a tnsnames.ora. Relative IFILE includes are parsed relative to dir.
+func Parse(text string, dir string) (*File, error) {
+	file := &File{descriptors: make(map[string]string)}
+	err := file.parse(filepath.Join(dir, FileName), text, make(map[string]bool))
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Resolve returns the connect descriptor of the alias, which is not case sensitive.
+// Returns an error if the connect descriptor is not valid, see Validate.
+func (file *File) Resolve(alias string) (string, error) {
+	descriptor, ok := file.descriptors[strings.ToUpper(alias)]
+	if !ok {
+		return "", fmt.Errorf("%v: %v", ErrAliasNotFound, alias)
+	}
+	err := Validate(descriptor)
+	if err != nil {
+		return "", fmt.Errorf("alias %v: %v", alias, err)
+	}
+	return descriptor, nil
+}
+
+// Aliases returns the upper case aliases of the file, sorted
+func (file *File) Aliases() []string {
+	aliases := make([]string, 0, len(file.descriptors))
+	for alias := range file.descriptors {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// Validate checks that the connect descriptor is a DESCRIPTION or DESCRIPTION_LIST,
+// each DESCRIPTION has an ADDRESS and a CONNECT_DATA with a SERVICE_NAME or SID,
+// and each ADDRESS has a PROTOCOL, and a HOST if the protocol is TCP or TCPS
+func Validate(descriptor string) error {
+	p := &parser{text: descriptor}
+	p.skipSpace()
+	root, err := p.parseNode()
+	if err != nil {
+		return err
+	}
+	p.skipSpace()
+	if p.pos < len(p.text) {
+		return p.errorf("unexpected text after the connect descriptor")
+	}
+	return root.validate()
+}
+
+// parseFile parses the file at path, skipping files already parsed so IFILE loops end
+func (file *File) parseFile(path string, parsed map[string]bool) error {
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if parsed[absolute] {
+		return nil
+	}
+	parsed[absolute] = true
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return file.parse(path, string(data), parsed)
+}
+
+// parse parses the text of the file at path
+func (file *File) parse(path string, text string, parsed map[string]bool) error {
+	p := &parser{path: path, text: text}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.text) {
+			return nil
+		}
+
+		aliases, err := p.parseAliases()
+		if err != nil {
+			return err
+		}
+
+		if len(aliases) == 1 && aliases[0] == "IFILE" {
+			include := p.parseLineValue()
+			if include == "" {
+				return p.errorf("IFILE without a file name")
+			}
+			if !filepath.IsAbs(include) {
+				include = filepath.Join(filepath.Dir(path), include)
+			}
+			err = file.parseFile(include, parsed)
+			if err != nil {
+				return fmt.Errorf("%v: IFILE: %v", path, err)
+			}
+			continue
+		}
+
+		p.skipSpace()
+		root, err := p.parseNode()
+		if err != nil {
+			return err
+		}
+		descriptor := root.String()
+
+		for _, alias := range aliases {
+			if _, ok := file.descriptors[alias]; !ok {
+				file.descriptors[alias] = descriptor
+			}
+		}
+	}
+}
+
+// parseAliases parses the comma separated aliases of an entry up to the =
+func (p *parser) parseAliases() ([]string, error) {
+	var aliases []string
+	for {
+		p.skipSpace()
+		start := p.pos
+		for p.pos < len(p.text) && isNameChar(p.text[p.pos]) {
+			p.pos++
+		}
+		if p.pos == start {
+			return nil, p.errorf("expected an alias")
+		}
+		aliases = append(aliases, strings.ToUpper(p.text[start:p.pos]))
+
+		p.skipSpace()
+		if p.pos >= len(p.text) {
+			return nil, p.errorf("expected = after alias")
+		}
+		switch p.text[p.pos] {
+		case ',':
+			p.pos++
+		case '=':
+			p.pos++
+			return aliases, nil
+		default:
+			return nil, p.errorf("expected = after alias")
+		}
+	}
+}
+
+// parseLineValue parses a value up to the end of the line or a comment, without quotes
+func (p *parser) parseLineValue() string {
+	for p.pos < len(p.text) && (p.text[p.pos] == ' ' || p.text[p.pos] == '\t') {
+		p.pos++
+	}
+	start := p.pos
+	for p.pos < len(p.text) && p.text[p.pos] != '\n' && p.text[p.pos] != '#' {
+		p.pos++
+	}
+	return strings.Trim(strings.TrimSpace(p.text[start:p.pos]), `"'`)
+}
+
+// parseNode parses (KEYWORD=value) or (KEYWORD=(...)(...))
+func (p *parser) parseNode() (*node, error) {
+	if p.pos >= len(p.text) || p.text[p.pos] != '(' {
+		return nil, p.errorf("expected (")
+	}
+	p.pos++
+
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.text) && isNameChar(p.text[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		return nil, p.errorf("expected a keyword")
+	}
+	n := &node{keyword: strings.ToUpper(p.text[start:p.pos])}
+
+	p.skipSpace()
+	if p.pos >= len(p.text) || p.text[p.pos] != '=' {
+		return nil, p.errorf("expected = after %v", n.keyword)
+	}
+	p.pos++
+	p.skipSpace()
+
+	if p.pos < len(p.text) && p.text[p.pos] == '(' {
+		for p.pos < len(p.text) && p.text[p.pos] == '(' {
+			child, err := p.parseNode()
+			if err != nil {
+				return nil, err
+			}
+			n.children = append(n.children, child)
+			p.skipSpace()
+		}
+	} else {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		n.value = value
+	}
+
+	if p.pos >= len(p.text) || p.text[p.pos] != ')' {
+		return nil, p.errorf("expected ) to end %v", n.keyword)
+	}
+	p.pos++
+	return n, nil
+}
+
+// parseValue parses a value up to the ), which can be quoted to contain parentheses
+func (p *parser) parseValue() (string, error) {
+	start := p.pos
+	var quote byte
+	for p.pos < len(p.text) {
+		c := p.text[p.pos]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			return "", p.errorf("unexpected ( in value")
+		case c == ')':
+			return strings.TrimSpace(p.text[start:p.pos]), nil
+		case c == '#':
+			return "", p.errorf("unexpected comment in value")
+		}
+		p.pos++
+	}
+	if quote != 0 {
+		return "", p.errorf("unterminated quote")
+	}
+	return "", p.errorf("expected ) after value")
+}
+
+// skipSpace skips white space and # comments
+func (p *parser) skipSpace() {
+	for p.pos < len(p.text) {
+		switch p.text[p.pos] {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		case '#':
+			for p.pos < len(p.text) && p.text[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// errorf returns an error with the path and line number of the current position
+func (p *parser) errorf(format string, a ...interface{}) error {
+	line := strings.Count(p.text[:p.pos], "\n") + 1
+	if p.path == "" {
+		return fmt.Errorf("line %v: %v", line, fmt.Sprintf(format, a...))
+	}
+	return fmt.Errorf("%v:%v: %v", p.path, line, fmt.Sprintf(format, a...))
+}
+
+// isNameChar returns true if c can be in an alias or keyword
+func isNameChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '.' || c == '-'
+}
+
+// String returns the node without white space between the parentheses
+func (n *node) String() string {
+	var builder strings.Builder
+	n.write(&builder)
+	return builder.String()
+}
+
+// write writes the node to builder
+func (n *node) write(builder *strings.Builder) {
+	builder.WriteString("(")
+	builder.WriteString(n.keyword)
+	builder.WriteString("=")
+	if len(n.children) > 0 {
+		for _, child := range n.children {
+			child.write(builder)
+		}
+	} else {
+		builder.WriteString(n.value)
+	}
+	builder.WriteString(")")
+}
+
+// child returns the first child with the keyword, or nil
+func (n *node) child(keyword string) *node {
+	for _, child := range n.children {
+		if child.keyword == keyword {
+			return child
+		}
+	}
+	return nil
+}
+
+// validate validates a DESCRIPTION_LIST or DESCRIPTION
+func (n *node) validate() error {
+	switch n.keyword {
+	case "DESCRIPTION_LIST":
+		descriptions := 0
+		for _, child := range n.children {
+			if child.keyword != "DESCRIPTION" {
+				continue
+			}
+			descriptions++
+			err := child.validateDescription()
+			if err != nil {
+				return err
+			}
+		}
+		if descriptions == 0 {
+			return errors.New("DESCRIPTION_LIST without a DESCRIPTION")
+		}
+		return nil
+	case "DESCRIPTION":
+		return n.validateDescription()
+	}
+	return fmt.Errorf("expected DESCRIPTION or DESCRIPTION_LIST, got %v", n.keyword)
+}
+
+// validateDescription validates the addresses and the connect data of a DESCRIPTION
+func (n *node) validateDescription() error {
+	addresses := 0
+	for _, child := range n.children {
+		switch child.keyword {
+		case "ADDRESS":
+			addresses++
+			err := child.validateAddress()
+			if err != nil {
+				return err
+			}
+		case "ADDRESS_LIST":
+			for _, address := range child.children {
+				if address.keyword != "ADDRESS" {
+					continue
+				}
+				addresses++
+				err := address.validateAddress()
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if addresses == 0 {
+		return errors.New("DESCRIPTION without an ADDRESS")
+	}
+
+	connectData := n.child("CONNECT_DATA")
+	if connectData == nil {
+		return errors.New("DESCRIPTION without CONNECT_DATA")
+	}
+	if connectData.child("SERVICE_NAME") == nil && connectData.child("SID") == nil {
+		return errors.New("CONNECT_DATA without SERVICE_NAME or SID")
+	}
+	return nil
+}
+
+// validateAddress validates the protocol and host of an ADDRESS
+func (n *node) validateAddress() error {
+	protocol := n.child("PROTOCOL")
+	if protocol == nil || protocol.value == "" {
+		return errors.New("ADDRESS without PROTOCOL")
+	}
+	switch strings.ToUpper(protocol.value) {
+	case "TCP", "TCPS":
+		if host := n.child("HOST"); host == nil || host.value == "" {
+			return errors.New("ADDRESS without HOST")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package tnsnames
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testTnsnames = `
+# production databases
+ORCL, orcl.example.com =
+  (DESCRIPTION =
+    (ADDRESS = (PROTOCOL = TCP)(HOST = db1.example.com)(PORT = 1521)) # primary
+    (CONNECT_DATA =
+      (SERVER = DEDICATED)
+      (SERVICE_NAME = orcl.example.com)
+    )
+  )
+
+STANDBY=(description=(address_list=(address=(protocol=tcp)(host=db2)(port=1521))(address=(protocol=tcp)(host=db3)(port=1521)))(connect_data=(sid=orcl)))
+
+# the first definition is used
+orcl = (DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(HOST=other)(PORT=1521))(CONNECT_DATA=(SERVICE_NAME=other)))
+
+SECURE =
+  (DESCRIPTION =
+    (ADDRESS = (PROTOCOL = TCPS)(HOST = db4)(PORT = 2484))
+    (SECURITY = (SSL_SERVER_CERT_DN = "CN=db4, O=Example (Test)"))
+    (CONNECT_DATA = (SERVICE_NAME = secure))
+  )
+
+NOCONNECTDATA = (DESCRIPTION = (ADDRESS = (PROTOCOL = TCP)(HOST = db5)(PORT = 1521)))
+
+IFILE = included.ora
+`
+
+const testIncluded = `
+INCLUDED = (DESCRIPTION = (ADDRESS = (PROTOCOL = IPC)(KEY = orcl))(CONNECT_DATA = (SERVICE_NAME = included)))
+# a loop back to the including file is ignored
+IFILE = "tnsnames.ora"
+`
+
+func TestParseFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tnsnames")
+	if err != nil {
+		t.Fatal("temp dir error:", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, FileName), []byte(testTnsnames), 0600)
+	if err != nil {
+		t.Fatal("write error:", err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "included.ora"), []byte(testIncluded), 0600)
+	if err != nil {
+		t.Fatal("write error:", err)
+	}
+
+	file, err := ParseFile(dir)
+	if err != nil {
+		t.Fatal("parse error:", err)
+	}
+
+	aliases := []string{"INCLUDED", "NOCONNECTDATA", "ORCL", "ORCL.EXAMPLE.COM", "SECURE", "STANDBY"}
+	if !reflect.DeepEqual(file.Aliases(), aliases) {
+		t.Fatalf("aliases - received: %v - expected: %v", file.Aliases(), aliases)
+	}
+
+	tests := []struct {
+		alias    string
+		expected string
+		err      string
+	}{
+		{"orcl", "(DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(HOST=db1.example.com)(PORT=1521))(CONNECT_DATA=(SERVER=DEDICATED)(SERVICE_NAME=orcl.example.com)))", ""},
+		{"ORCL.example.com", "(DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(HOST=db1.example.com)(PORT=1521))(CONNECT_DATA=(SERVER=DEDICATED)(SERVICE_NAME=orcl.example.com)))", ""},
+		{"standby", "(DESCRIPTION=(ADDRESS_LIST=(ADDRESS=(PROTOCOL=tcp)(HOST=db2)(PORT=1521))(ADDRESS=(PROTOCOL=tcp)(HOST=db3)(PORT=1521)))(CONNECT_DATA=(SID=orcl)))", ""},
+		{"secure", `(DESCRIPTION=(ADDRESS=(PROTOCOL=TCPS)(HOST=db4)(PORT=2484))(SECURITY=(SSL_SERVER_CERT_DN="CN=db4, O=Example (Test)"))(CONNECT_DATA=(SERVICE_NAME=secure)))`, ""},
+		{"included", "(DESCRIPTION=(ADDRESS=(PROTOCOL=IPC)(KEY=orcl))(CONNECT_DATA=(SERVICE_NAME=included)))", ""},
+		{"noconnectdata", "", "CONNECT_DATA"},
+		{"missing", "", "alias not found"},
+	}
+
+	for _, tt := range tests {
+		actual, err := file.Resolve(tt.alias)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Resolve(%v) error - received: %v - expected: %v", tt.alias, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Resolve(%v) error: %v", tt.alias, err)
+			continue
+		}
+		if actual != tt.expected {
+			t.Errorf("Resolve(%v) - received: %v - expected: %v", tt.alias, actual, tt.expected)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		text string
+		err  string
+	}{
+		{"ORCL (DESCRIPTION=)", "tnsnames.ora:1: expected = after alias"},
+		{"ORCL =\n  (DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)", "tnsnames.ora:2: expected ) to end ADDRESS"},
+		{"ORCL = (DESCRIPTION=(ADDRESS=(HOST=\"a)))", "tnsnames.ora:1: unterminated quote"},
+		{"= (DESCRIPTION=)", "tnsnames.ora:1: expected an alias"},
+		{"IFILE =\n", "tnsnames.ora:1: IFILE without a file name"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.text, "")
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("Parse(%q) error - received: %v - expected: %v", tt.text, err, tt.err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		descriptor string
+		err        string
+	}{
+		{"(DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(HOST=a)(PORT=1521))(CONNECT_DATA=(SERVICE_NAME=b)))", ""},
+		{" (DESCRIPTION_LIST=(DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(HOST=a))(CONNECT_DATA=(SID=b))))", ""},
+		{"(ADDRESS=(PROTOCOL=TCP)(HOST=a))", "expected DESCRIPTION"},
+		{"(DESCRIPTION_LIST=(FAILOVER=on))", "without a DESCRIPTION"},
+		{"(DESCRIPTION=(CONNECT_DATA=(SERVICE_NAME=b)))", "without an ADDRESS"},
+		{"(DESCRIPTION=(ADDRESS=(HOST=a))(CONNECT_DATA=(SERVICE_NAME=b)))", "without PROTOCOL"},
+		{"(DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(PORT=1))(CONNECT_DATA=(SERVICE_NAME=b)))", "without HOST"},
+		{"(DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(HOST=a))(CONNECT_DATA=(SERVER=DEDICATED)))", "without SERVICE_NAME or SID"},
+		{"(DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(HOST=a))(CONNECT_DATA=(SID=b)))x", "unexpected text"},
+	}
+
+	for _, tt := range tests {
+		err := Validate(tt.descriptor)
+		if tt.err == "" {
+			if err != nil {
+				t.Errorf("Validate(%v) error: %v", tt.descriptor, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("Validate(%v) error - received: %v - expected: %v", tt.descriptor, err, tt.err)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tnsnames")
+	if err != nil {
+		t.Fatal("temp dir error:", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, FileName), []byte(testTnsnames), 0600)
+	if err != nil {
+		t.Fatal("write error:", err)
+	}
+
+	tnsAdmin, oracleHome := os.Getenv("TNS_ADMIN"), os.Getenv("ORACLE_HOME")
+	defer func() {
+		os.Setenv("TNS_ADMIN", tnsAdmin)
+		os.Setenv("ORACLE_HOME", oracleHome)
+	}()
+
+	os.Setenv("TNS_ADMIN", dir)
+	os.Unsetenv("ORACLE_HOME")
+	path, err := Find()
+	if err != nil {
+		t.Fatal("find error:", err)
+	}
+	if path != filepath.Join(dir, FileName) {
+		t.Fatalf("path - received: %v - expected: %v", path, filepath.Join(dir, FileName))
+	}
+
+	os.Setenv("TNS_ADMIN", filepath.Join(dir, "missing"))
+	_, err = Find()
+	if err != ErrNotFound {
+		t.Fatalf("find error - received: %v - expected: %v", err, ErrNotFound)
+	}
+}
@@ -0,0 +1,52 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"unsafe"
+)
+
+// InTransaction returns true if the session has a transaction in progress on the server.
+// Unlike the BeginTx state, it sees transactions started by statements outside BeginTx when autocommit is false,
+// and transactions ended by DDL or by PL/SQL that commits or rolls back.
+// The state is from the last round trip to the server, so it does not cause a round trip.
+// It does not change the BeginTx state: after DDL or PL/SQL ends the transaction inside BeginTx,
+// InTransaction returns false, but later statements are still not committed until Commit, see autocommit.
+func (conn *Conn) InTransaction() bool {
+	inProgress, err := conn.ociTransactionInProgress()
+	if err != nil {
+		return conn.inTransaction
+	}
+	return inProgress
+}
+
+// autocommit returns true if statements are committed on success.
+// It uses the BeginTx state, not OCI_ATTR_TRANSACTION_IN_PROGRESS: when DDL or PL/SQL ends the transaction inside BeginTx,
+// the statements after it start a new server transaction that Commit or Rollback of the Tx still ends,
+// as committing them on success would make Rollback leave them committed.
+// The server state is consulted by InTransaction, ResetSession, and when Commit or Rollback fail.
+func (conn *Conn) autocommit() bool {
+	return !conn.inTransaction && !conn.disableAutocommit
+}
+
+// ociTransactionInProgress gets OCI_ATTR_TRANSACTION_IN_PROGRESS of the session
+func (conn *Conn) ociTransactionInProgress() (bool, error) {
+	var inProgress C.boolean
+	_, err := conn.ociAttrGetHandle(unsafe.Pointer(conn.usrSession), C.OCI_HTYPE_SESSION, unsafe.Pointer(&inProgress), C.OCI_ATTR_TRANSACTION_IN_PROGRESS)
+	if err != nil {
+		return false, err
+	}
+	return inProgress != 0, nil
+}
+
+// ociTransRollback calls OCITransRollback
+func (conn *Conn) ociTransRollback() error {
+	result := C.OCITransRollback(
+		conn.svc,       // service context handle
+		conn.errHandle, // error handle
+		C.OCI_DEFAULT,  // flags, not used
+	)
+	conn.addRoundTrips(1)
+	return conn.getError(result)
+}
@@ -0,0 +1,252 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+	"unsafe"
+)
+
+// XA start modes
+const (
+	// XAStartNew starts a new branch of the global transaction
+	XAStartNew XAStartMode = iota
+	// XAStartJoin joins an existing branch of the global transaction that is attached to another session
+	XAStartJoin
+	// XAStartResume resumes a branch of the global transaction that was detached with XADetach
+	XAStartResume
+)
+
+// XAStart starts, joins, or resumes the branch xid of a distributed transaction on conn.
+// Timeout, truncated to whole seconds, is how long the branch can stay detached before it is rolled back for XAStartNew,
+// or how long to wait for the branch to become available for XAStartJoin and XAStartResume.
+// Statements executed on conn are part of the branch until XADetach, XAPrepare, XACommit, or XARollback.
+func XAStart(ctx context.Context, conn driver.Conn, xid XID, mode XAStartMode, timeout time.Duration) error {
+	oci8Conn, err := toConn(conn)
+	if err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	err = oci8Conn.ociSetXID(&xid)
+	if err != nil {
+		oci8Conn.ociResetTransaction()
+		return err
+	}
+
+	var flags C.ub4
+	switch mode {
+	case XAStartJoin:
+		flags = C.OCI_TRANS_JOIN
+	case XAStartResume:
+		flags = C.OCI_TRANS_RESUME
+	default:
+		flags = C.OCI_TRANS_NEW
+		if oci8Conn.transactionMode != C.OCI_TRANS_READWRITE {
+			flags |= oci8Conn.transactionMode
+		}
+	}
+
+	done := oci8Conn.ociBreakOnDone(ctx)
+	result := C.OCITransStart(
+		oci8Conn.svc,                 // service context handle
+		oci8Conn.errHandle,           // error handle
+		C.uword(timeout/time.Second), // timeout in seconds
+		flags,                        // mode
+	)
+	closeDone(done)
+	if result != C.OCI_SUCCESS {
+		err = oci8Conn.getError(result)
+		oci8Conn.ociResetTransaction()
+		return err
+	}
+
+	oci8Conn.inTransaction = true
+
+	return nil
+}
+
+// XADetach detaches the branch that is attached to conn so it can be resumed on another connection with XAStartResume.
+func XADetach(ctx context.Context, conn driver.Conn) error {
+	oci8Conn, err := toConn(conn)
+	if err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	done := oci8Conn.ociBreakOnDone(ctx)
+	result := C.OCITransDetach(
+		oci8Conn.svc,       // service context handle
+		oci8Conn.errHandle, // error handle
+		C.OCI_DEFAULT,      // flags, not used
+	)
+	closeDone(done)
+	if result != C.OCI_SUCCESS {
+		return oci8Conn.getError(result)
+	}
+
+	return oci8Conn.ociResetTransaction()
+}
+
+// XAPrepare prepares the branch xid for a two-phase commit.
+// If xid is nil, the branch attached to conn is prepared.
+// Returns true if the branch made no changes, then it is complete and must not be committed.
+// The transaction handle is reset either way, so the branch is committed or rolled back by xid, from conn or another connection.
+func XAPrepare(ctx context.Context, conn driver.Conn, xid *XID) (bool, error) {
+	oci8Conn, err := toConn(conn)
+	if err != nil {
+		return false, err
+	}
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	err = oci8Conn.ociSetXID(xid)
+	if err != nil {
+		oci8Conn.ociResetTransaction()
+		return false, err
+	}
+
+	done := oci8Conn.ociBreakOnDone(ctx)
+	result := C.OCITransPrepare(
+		oci8Conn.svc,       // service context handle
+		oci8Conn.errHandle, // error handle
+		C.OCI_DEFAULT,      // flags, not used
+	)
+	closeDone(done)
+	oci8Conn.inTransaction = false
+
+	readOnly := false
+	if result == C.OCI_SUCCESS_WITH_INFO {
+		errorCode, _ := oci8Conn.ociGetError()
+		// ORA-24767: transaction branch prepare returns read-only
+		readOnly = errorCode == 24767
+	}
+	if result != C.OCI_SUCCESS && !readOnly {
+		err = oci8Conn.getError(result)
+		oci8Conn.ociResetTransaction()
+		return false, err
+	}
+
+	return readOnly, oci8Conn.ociResetTransaction()
+}
+
+// XACommit commits the branch xid.
+// If xid is nil, the branch attached to conn is committed.
+// When onePhase is true the branch is committed without being prepared, otherwise it must have been prepared with XAPrepare,
+// which resets the transaction handle, so xid must be given.
+func XACommit(ctx context.Context, conn driver.Conn, xid *XID, onePhase bool) error {
+	flags := C.ub4(C.OCI_TRANS_TWOPHASE)
+	if onePhase {
+		flags = C.OCI_DEFAULT
+	}
+
+	return xaEnd(ctx, conn, xid, func(oci8Conn *Conn) C.sword {
+		return C.OCITransCommit(
+			oci8Conn.svc,       // service context handle
+			oci8Conn.errHandle, // error handle
+			flags,              // flags
+		)
+	})
+}
+
+// XARollback rolls back the branch xid.
+// If xid is nil, the branch attached to conn is rolled back.
+func XARollback(ctx context.Context, conn driver.Conn, xid *XID) error {
+	return xaEnd(ctx, conn, xid, func(oci8Conn *Conn) C.sword {
+		return C.OCITransRollback(
+			oci8Conn.svc,       // service context handle
+			oci8Conn.errHandle, // error handle
+			C.OCI_DEFAULT,      // flags, not used
+		)
+	})
+}
+
+// XAForget tells the database to forget the heuristically completed branch xid.
+func XAForget(ctx context.Context, conn driver.Conn, xid XID) error {
+	return xaEnd(ctx, conn, &xid, func(oci8Conn *Conn) C.sword {
+		return C.OCITransForget(
+			oci8Conn.svc,       // service context handle
+			oci8Conn.errHandle, // error handle
+			C.OCI_DEFAULT,      // flags, not used
+		)
+	})
+}
+
+// xaEnd sets xid then calls the function that ends the branch,
+// then resets the transaction handle so later transactions on conn are local
+func xaEnd(ctx context.Context, conn driver.Conn, xid *XID, end func(oci8Conn *Conn) C.sword) error {
+	oci8Conn, err := toConn(conn)
+	if err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	err = oci8Conn.ociSetXID(xid)
+	if err != nil {
+		oci8Conn.ociResetTransaction()
+		return err
+	}
+
+	done := oci8Conn.ociBreakOnDone(ctx)
+	result := end(oci8Conn)
+	closeDone(done)
+	oci8Conn.inTransaction = false
+	if result != C.OCI_SUCCESS {
+		err = oci8Conn.getError(result)
+		oci8Conn.ociResetTransaction()
+		return err
+	}
+
+	return oci8Conn.ociResetTransaction()
+}
+
+// ociSetXID sets the XID of the transaction handle, does nothing if xid is nil
+func (conn *Conn) ociSetXID(xid *XID) error {
+	if xid == nil {
+		return nil
+	}
+	if len(xid.GlobalTransactionID) > 64 || len(xid.BranchQualifier) > 64 {
+		return ErrXIDTooLong
+	}
+
+	var cXID C.XID
+	cXID.formatID = C.long(xid.FormatID)
+	cXID.gtrid_length = C.long(len(xid.GlobalTransactionID))
+	cXID.bqual_length = C.long(len(xid.BranchQualifier))
+	data := (*[C.XIDDATASIZE]byte)(unsafe.Pointer(&cXID.data[0]))
+	copy(data[:], xid.GlobalTransactionID)
+	copy(data[len(xid.GlobalTransactionID):], xid.BranchQualifier)
+
+	return conn.ociAttrSet(unsafe.Pointer(conn.txHandle), C.OCI_HTYPE_TRANS, unsafe.Pointer(&cXID), C.ub4(unsafe.Sizeof(cXID)), C.OCI_ATTR_XID)
+}
+
+// ociResetTransaction replaces the transaction handle of the service context with a new one without a XID
+func (conn *Conn) ociResetTransaction() error {
+	handle, _, err := conn.ociHandleAlloc(C.OCI_HTYPE_TRANS, 0)
+	if err != nil {
+		return err
+	}
+
+	err = conn.ociAttrSet(unsafe.Pointer(conn.svc), C.OCI_HTYPE_SVCCTX, *handle, 0, C.OCI_ATTR_TRANS)
+	if err != nil {
+		C.OCIHandleFree(*handle, C.OCI_HTYPE_TRANS)
+		return err
+	}
+
+	C.OCIHandleFree(unsafe.Pointer(conn.txHandle), C.OCI_HTYPE_TRANS)
+	conn.txHandle = (*C.OCITrans)(*handle)
+	conn.inTransaction = false
+	conn.savepoints = nil
+
+	return nil
+}